@@ -2,8 +2,11 @@
 package extender
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"time"
@@ -81,29 +84,110 @@ func errorHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotFound)
 }
 
-// StartServer starts the HTTP server needed for the scheduler extender.
-// It registers the handlers and checks for existing telemetry policies.
-func (m Server) StartServer(port string, certFile string, keyFile string, caFile string, unsafe bool) {
+// shutdownTimeout is how long in-flight requests are waited for when the server shuts down.
+const shutdownTimeout = 30 * time.Second
+
+// healthz reports that the server is alive.
+func healthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// readyz reports whether the scheduler is ready to serve scheduling requests.
+func (m Server) readyz(w http.ResponseWriter, r *http.Request) {
+	if checker, ok := m.Scheduler.(ReadinessChecker); ok && !checker.Ready() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("not ready"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// healthHandler returns the handler which serves only the health endpoints.
+func (m Server) healthHandler() http.Handler {
+	mx := http.NewServeMux()
+	mx.HandleFunc("/healthz", healthz)
+	mx.HandleFunc("/readyz", m.readyz)
+	return mx
+}
+
+// handler returns the handler which serves the scheduler endpoints, the health endpoints and
+// the additional endpoints of the scheduler.
+func (m Server) handler() http.Handler {
 	mx := http.NewServeMux()
 	mx.HandleFunc("/", handlerWithMiddleware(errorHandler))
 	mx.HandleFunc("/scheduler/prioritize", handlerWithMiddleware(m.Prioritize))
 	mx.HandleFunc("/scheduler/filter", handlerWithMiddleware(m.Filter))
 	mx.HandleFunc("/scheduler/bind", handlerWithMiddleware(m.Bind))
-	var err error
-	if unsafe {
-		klog.V(2).InfoS("Extender Listening on HTTP "+port, "component", "extender")
-		err = http.ListenAndServe(":"+port, mx)
-		if err != nil {
-			klog.V(2).InfoS("Listening on HTTP failed: "+err.Error(), "component", "extender")
+	mx.HandleFunc("/healthz", healthz)
+	mx.HandleFunc("/readyz", m.readyz)
+	if routeProvider, ok := m.Scheduler.(RouteProvider); ok {
+		for pattern, handler := range routeProvider.Routes() {
+			mx.HandleFunc(pattern, handler)
 		}
+	}
+	return mx
+}
+
+// StartServer starts the HTTP server needed for the scheduler extender and serves until the context is done.
+// It then stops accepting new requests and waits for the in-flight requests, e.g. binds, to finish
+// before it returns. An error is returned if the server fails or doesn't shut down in time.
+// If the HealthPort is set, the health endpoints are also served over plain HTTP on that port.
+func (m Server) StartServer(ctx context.Context, port string, certFile string, keyFile string, caFile string,
+	unsafe bool) error {
+	var srv *http.Server
+	if unsafe {
+		srv = &http.Server{Addr: ":" + port, ReadHeaderTimeout: 5 * time.Second}
 	} else {
-		srv := configureSecureServer(port, caFile)
-		srv.Handler = mx
-		klog.V(2).InfoS("Extender Listening on HTTPS "+port, "component", "extender")
+		srv = configureSecureServer(port, caFile)
+	}
+	srv.Handler = m.handler()
+	servers := []*http.Server{srv}
+
+	serveErr := make(chan error, 2)
+	go func() {
+		if unsafe {
+			klog.V(2).InfoS("Extender Listening on HTTP "+port, "component", "extender")
+			serveErr <- srv.ListenAndServe()
+		} else {
+			klog.V(2).InfoS("Extender Listening on HTTPS "+port, "component", "extender")
+			serveErr <- srv.ListenAndServeTLS(certFile, keyFile)
+		}
+	}()
+	if m.HealthPort != "" {
+		healthSrv := &http.Server{Addr: ":" + m.HealthPort, Handler: m.healthHandler(), ReadHeaderTimeout: 5 * time.Second}
+		servers = append(servers, healthSrv)
+		go func() {
+			klog.V(2).InfoS("Extender health endpoints listening on HTTP "+m.HealthPort, "component", "extender")
+			serveErr <- healthSrv.ListenAndServe()
+		}()
+	}
 
-		klog.Fatal(srv.ListenAndServeTLS(certFile, keyFile))
+	running := len(servers)
+	var failure error
+	select {
+	case err := <-serveErr:
+		klog.V(2).InfoS("Scheduler extender server failed: "+err.Error(), "component", "extender")
+		failure = fmt.Errorf("scheduler extender server failed: %w", err)
+		running--
+	case <-ctx.Done():
+	}
+
+	klog.V(2).InfoS("Scheduler extender server shutting down", "component", "extender")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	for _, s := range servers {
+		if err := s.Shutdown(shutdownCtx); err != nil && failure == nil {
+			failure = fmt.Errorf("scheduler extender server shutdown failed: %w", err)
+		}
+	}
+	for ; running > 0; running-- {
+		if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) && failure == nil {
+			failure = fmt.Errorf("scheduler extender server failed: %w", err)
+		}
 	}
-	klog.V(2).InfoS("Scheduler extender server failed to start "+err.Error(), "component", "extender")
+	return failure
 }
 
 // Configuration values including algorithms etc for the TAS scheduling endpoint.
@@ -14,9 +14,24 @@ type Scheduler interface {
 	Filter(w http.ResponseWriter, r *http.Request)
 }
 
+// RouteProvider is implemented by schedulers which serve additional endpoints next to the scheduler endpoints.
+// The handlers are registered as they are, without the scheduler request checks.
+type RouteProvider interface {
+	Routes() map[string]http.HandlerFunc
+}
+
+// ReadinessChecker is implemented by schedulers which need time to become ready after they are created,
+// e.g. to fill their caches. Schedulers which don't implement it are ready right away.
+type ReadinessChecker interface {
+	Ready() bool
+}
+
 // Server type wraps the implementation of the extender.
 type Server struct {
 	Scheduler
+	// HealthPort is the port on which the health endpoints are also served over plain HTTP, e.g. for
+	// kubelet probes which can't authenticate with a client certificate. No such port is opened if empty.
+	HealthPort string
 }
 
 // TODO: These types are in the k8s.io/kubernetes/extender/api package
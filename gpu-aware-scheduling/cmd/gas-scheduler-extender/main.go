@@ -1,28 +1,50 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/intel/platform-aware-scheduling/extender"
 	"github.com/intel/platform-aware-scheduling/gpu-aware-scheduling/pkg/gpuscheduler"
 	"k8s.io/klog/v2"
 )
 
+const defaultPodGroupTimeout = 5 * time.Minute
+
 func main() {
 	var (
-		kubeConfig, port, certFile, keyFile, caFile, balancedRes string
-		enableAllowlist, enableDenylist                          bool
+		kubeConfig, port, certFile, keyFile, caFile, balancedRes, defaultPolicy string
+		healthPort, leaderElectionNamespace, leaderElectionID                   string
+		enableAllowlist, enableDenylist, leaderElect                            bool
+		podGroupTimeout                                                         time.Duration
 	)
 
 	flag.StringVar(&kubeConfig, "kubeConfig", "/root/.kube/config", "location of kubernetes config file")
 	flag.StringVar(&port, "port", "9001", "port on which the scheduler extender will listen")
+	flag.StringVar(&healthPort, "healthPort", "",
+		"port on which the health endpoints are also served over plain http, disabled if empty")
 	flag.StringVar(&certFile, "cert", "/etc/kubernetes/pki/ca.crt", "cert file extender will use for authentication")
 	flag.StringVar(&keyFile, "key", "/etc/kubernetes/pki/ca.key", "key file extender will use for authentication")
 	flag.StringVar(&caFile, "cacert", "/etc/kubernetes/pki/ca.crt", "ca file extender will use for authentication")
 	flag.BoolVar(&enableAllowlist, "enableAllowlist", false, "enable allowed GPUs annotation (csv list of names)")
 	flag.BoolVar(&enableDenylist, "enableDenylist", false, "enable denied GPUs annotation (csv list of names)")
-	flag.StringVar(&balancedRes, "balancedResource", "", "enable resource balacing within a node")
+	flag.StringVar(&balancedRes, "balancedResource", "",
+		"enable resource balacing within a node, csv list of resources with optional weights (millicores=2,memory.max)")
+	flag.StringVar(&defaultPolicy, "defaultPolicy", "preferred-card",
+		"scoring policy for pods without the gas-policy annotation: binpack, spread, tiles, balanced:<resource>"+
+			" or preferred-card")
+	flag.DurationVar(&podGroupTimeout, "podGroupTimeout", defaultPodGroupTimeout,
+		"time after which the gpu reservations of an incomplete pod group are released")
+	flag.BoolVar(&leaderElect, "leaderElect", false,
+		"elect a leader among the extender replicas, only the leader binds pods")
+	flag.StringVar(&leaderElectionNamespace, "leaderElectionNamespace", "default",
+		"namespace of the leader election lease")
+	flag.StringVar(&leaderElectionID, "leaderElectionID", "gas-scheduler-extender",
+		"name of the leader election lease")
 	klog.InitFlags(nil)
 	flag.Parse()
 
@@ -32,8 +54,35 @@ func main() {
 		os.Exit(1)
 	}
 
-	gasscheduler := gpuscheduler.NewGASExtender(kubeClient, enableAllowlist, enableDenylist, balancedRes)
-	sch := extender.Server{Scheduler: gasscheduler}
-	sch.StartServer(port, certFile, keyFile, caFile, false)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	gasscheduler := gpuscheduler.NewGASExtender(kubeClient, enableAllowlist, enableDenylist, balancedRes, defaultPolicy,
+		podGroupTimeout)
+
+	if leaderElect {
+		identity, err := os.Hostname()
+		if err != nil {
+			klog.Error("couldn't get hostname for leader election, cannot continue: ", err.Error())
+			os.Exit(1)
+		}
+
+		err = gasscheduler.StartLeaderElection(ctx, leaderElectionNamespace, leaderElectionID, identity)
+		if err != nil {
+			klog.Error("couldn't start leader election, cannot continue: ", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	sch := extender.Server{Scheduler: gasscheduler, HealthPort: healthPort}
+
+	err = sch.StartServer(ctx, port, certFile, keyFile, caFile, false)
+	stop()
+
+	if err != nil {
+		klog.Error("scheduler extender server failed: ", err.Error())
+		klog.Flush()
+		os.Exit(1)
+	}
+
 	klog.Flush()
 }
@@ -1,6 +1,8 @@
 package gpuscheduler
 
 import (
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 	kubernetes "k8s.io/client-go/kubernetes"
 )
@@ -15,6 +17,10 @@ func (r *cacheAPI) FetchNode(cache *Cache, nodeName string) (*v1.Node, error) {
 	return cache.fetchNode(nodeName)
 }
 
+func (r *cacheAPI) FetchNodes(cache *Cache) ([]*v1.Node, error) {
+	return cache.fetchNodes()
+}
+
 func (r *cacheAPI) FetchPod(cache *Cache, podNs, podName string) (*v1.Pod, error) {
 	return cache.fetchPod(podNs, podName)
 }
@@ -28,6 +34,35 @@ func (r *cacheAPI) AdjustPodResourcesL(cache *Cache, pod *v1.Pod, adj bool, anno
 	return cache.adjustPodResourcesL(pod, adj, annotation, tileAnnotation, nodeName)
 }
 
+func (r *cacheAPI) IsPodGroupMemberBound(cache *Cache, group string, pod *v1.Pod) bool {
+	return cache.isPodGroupMemberBound(group, pod)
+}
+
+func (r *cacheAPI) IsPodGroupMemberReserved(cache *Cache, group string, pod *v1.Pod) bool {
+	return cache.isPodGroupMemberReserved(group, pod)
+}
+
+func (r *cacheAPI) ReservePodGroupMemberL(cache *Cache, group string, size int, timeout time.Duration,
+	member podGroupMember) ([]podGroupMember, error) {
+	return cache.reservePodGroupMemberL(group, size, timeout, member)
+}
+
 func (r *cacheAPI) GetNodeTileStatus(cache *Cache, nodeName string) nodeTiles {
 	return cache.getNodeTileStatus(nodeName)
 }
+
+func (r *cacheAPI) GetNodeCardPods(cache *Cache, nodeName string) cardPods {
+	return cache.getNodeCardPods(nodeName)
+}
+
+func (r *cacheAPI) GetReconcileProblems(cache *Cache) []reconcileProblem {
+	return cache.getReconcileProblems()
+}
+
+func (r *cacheAPI) IsReady(cache *Cache) bool {
+	return cache.isReady()
+}
+
+func (r *cacheAPI) RebuildL(cache *Cache) error {
+	return cache.rebuildL()
+}
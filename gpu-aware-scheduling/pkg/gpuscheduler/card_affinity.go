@@ -0,0 +1,122 @@
+package gpuscheduler
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+const (
+	cardAffinityAnnotationName     = "gas-card-affinity"
+	cardAntiAffinityAnnotationName = "gas-card-anti-affinity"
+)
+
+// cardAffinity has the card affinity terms of a pod, which are label selectors of the other pods using
+// the cards. The pod prefers the cards used by the pods its affinity selects, and doesn't share a card
+// with the pods its anti-affinity selects.
+type cardAffinity struct {
+	affinity     labels.Selector
+	antiAffinity labels.Selector
+}
+
+// podCardAffinity returns the card affinity terms in the annotations of the pod. The annotations have
+// Kubernetes label selectors, e.g. "job=train" or "tier in (batch,test)". A missing annotation selects
+// no pods.
+func podCardAffinity(pod *v1.Pod) (cardAffinity, error) {
+	affinity, err := podAnnotationSelector(pod, cardAffinityAnnotationName)
+	if err != nil {
+		return cardAffinity{}, err
+	}
+
+	antiAffinity, err := podAnnotationSelector(pod, cardAntiAffinityAnnotationName)
+	if err != nil {
+		return cardAffinity{}, err
+	}
+
+	return cardAffinity{affinity: affinity, antiAffinity: antiAffinity}, nil
+}
+
+// podAnnotationSelector parses the label selector in the given annotation of the pod.
+func podAnnotationSelector(pod *v1.Pod, annotationName string) (labels.Selector, error) {
+	annotation, ok := pod.Annotations[annotationName]
+	if !ok {
+		return labels.Nothing(), nil
+	}
+
+	selector, err := labels.Parse(annotation)
+	if err != nil {
+		return nil, fmt.Errorf("bad %v annotation: %w", annotationName, err)
+	}
+
+	return selector, nil
+}
+
+// antiAffinityReason returns the reason why the pod can't share a gpu with the given pods using it due to
+// card anti-affinity, or an empty string if it can. The anti-affinity of the pods using the gpu counts too,
+// so that the pods selected by it don't get the gpu later.
+func (a cardAffinity) antiAffinityReason(pod *v1.Pod, gpuPods map[string]*v1.Pod) string {
+	podKey := getKey(pod)
+	keys := make([]string, 0, len(gpuPods))
+
+	for key := range gpuPods {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == podKey {
+			continue
+		}
+
+		otherPod := gpuPods[key]
+		if a.antiAffinity.Matches(labels.Set(otherPod.Labels)) {
+			return "card anti-affinity with pod " + namespacedPodName(otherPod)
+		}
+
+		otherAffinity, err := podCardAffinity(otherPod)
+		if err != nil {
+			klog.V(l4).Infof("ignoring the card affinity of pod %v: %v", namespacedPodName(otherPod), err)
+
+			continue
+		}
+
+		if otherAffinity.antiAffinity.Matches(labels.Set(pod.Labels)) {
+			return "card anti-affinity of pod " + namespacedPodName(otherPod)
+		}
+	}
+
+	return ""
+}
+
+// hasAffinity returns true if the affinity of the pod selects any other pod using the gpu.
+func (a cardAffinity) hasAffinity(pod *v1.Pod, gpuPods map[string]*v1.Pod) bool {
+	podKey := getKey(pod)
+
+	for key, otherPod := range gpuPods {
+		if key != podKey && a.affinity.Matches(labels.Set(otherPod.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// moveAffineGPUsToFront moves the gpus used by the pods which the affinity of the pod selects to the front,
+// keeping the order of the gpus otherwise. It returns true if the first gpu changed.
+func (a cardAffinity) moveAffineGPUsToFront(gpuNames []string, pod *v1.Pod, nodeCardPods cardPods) bool {
+	if len(gpuNames) == 0 {
+		return false
+	}
+
+	first := gpuNames[0]
+
+	sort.SliceStable(gpuNames, func(i, j int) bool {
+		return a.hasAffinity(pod, nodeCardPods[gpuNames[i]]) && !a.hasAffinity(pod, nodeCardPods[gpuNames[j]])
+	})
+
+	return gpuNames[0] != first
+}
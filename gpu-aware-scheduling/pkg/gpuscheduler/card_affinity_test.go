@@ -0,0 +1,157 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func getLabeledPod(name string, podLabels map[string]string) *v1.Pod {
+	pod := getFakePod()
+	pod.Name = name
+	pod.Namespace = "default"
+	pod.Labels = podLabels
+
+	return pod
+}
+
+func TestPodCardAffinity(t *testing.T) {
+	Convey("When the pod has no card affinity annotations, no pods are selected", t, func() {
+		affinity, err := podCardAffinity(getFakePod())
+		So(err, ShouldBeNil)
+		So(affinity.affinity.Matches(labels.Set{"job": "train"}), ShouldBeFalse)
+		So(affinity.antiAffinity.Matches(labels.Set{"job": "train"}), ShouldBeFalse)
+	})
+
+	Convey("When a card affinity annotation is bad, an error is returned", t, func() {
+		pod := getFakePod()
+		pod.Annotations[cardAntiAffinityAnnotationName] = "job in (train"
+		_, err := podCardAffinity(pod)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldStartWith, "bad gas-card-anti-affinity annotation")
+	})
+}
+
+func TestAntiAffinityReason(t *testing.T) {
+	pod := getLabeledPod("pod1", map[string]string{"job": "infer"})
+	pod.Annotations[cardAntiAffinityAnnotationName] = "noisy"
+
+	affinity, err := podCardAffinity(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("When a pod using the gpu is selected by the anti-affinity, the gpu can't be shared", t, func() {
+		noisyPod := getLabeledPod("pod2", map[string]string{"noisy": "true"})
+		So(affinity.antiAffinityReason(pod, map[string]*v1.Pod{getKey(noisyPod): noisyPod}), ShouldEqual,
+			"card anti-affinity with pod default/pod2")
+	})
+
+	Convey("When the anti-affinity of a pod using the gpu selects the pod, the gpu can't be shared", t, func() {
+		otherPod := getLabeledPod("pod3", nil)
+		otherPod.Annotations[cardAntiAffinityAnnotationName] = "job=infer"
+		So(affinity.antiAffinityReason(pod, map[string]*v1.Pod{getKey(otherPod): otherPod}), ShouldEqual,
+			"card anti-affinity of pod default/pod3")
+	})
+
+	Convey("When the gpu is only used by the pod itself, it can be shared", t, func() {
+		So(affinity.antiAffinityReason(pod, map[string]*v1.Pod{getKey(pod): pod}), ShouldEqual, "")
+	})
+}
+
+func TestMoveAffineGPUsToFront(t *testing.T) {
+	pod := getLabeledPod("pod1", nil)
+	pod.Annotations[cardAffinityAnnotationName] = "job=train"
+
+	affinity, err := podCardAffinity(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trainPod := getLabeledPod("pod2", map[string]string{"job": "train"})
+	otherPod := getLabeledPod("pod3", map[string]string{"job": "infer"})
+	nodeCardPods := cardPods{
+		"card1": {getKey(otherPod): otherPod},
+		"card2": {getKey(trainPod): trainPod},
+	}
+
+	Convey("When the affinity selects a pod using a gpu, the gpu is moved to the front", t, func() {
+		gpuNames := []string{"card0", "card1", "card2", "card3"}
+		So(affinity.moveAffineGPUsToFront(gpuNames, pod, nodeCardPods), ShouldBeTrue)
+		So(gpuNames, ShouldResemble, []string{"card2", "card0", "card1", "card3"})
+	})
+
+	Convey("When the affinity selects no pod, the gpus keep their order", t, func() {
+		gpuNames := []string{"card0", "card1", "card3"}
+		So(affinity.moveAffineGPUsToFront(gpuNames, pod, nodeCardPods), ShouldBeFalse)
+		So(gpuNames, ShouldResemble, []string{"card0", "card1", "card3"})
+	})
+}
+
+func TestCardAffinityCardsForContainerGPURequest(t *testing.T) {
+	gas := getDummyExtender()
+	node := getMockNode(2, 1, "card0", "card1")
+
+	containerRequest := resourceMap{"gpu.intel.com/i915": 1}
+	gpuCapacities := nodeResources{"card0": resourceMap{"gpu.intel.com/i915": 2},
+		"card1": resourceMap{"gpu.intel.com/i915": 2}}
+	gpuMap := map[string]bool{"card0": true, "card1": true}
+
+	trainPod := getLabeledPod("pod2", map[string]string{"job": "train"})
+
+	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, node.Name).Return(cardPods{"card1": {getKey(trainPod): trainPod}})
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	Convey("When the pod has affinity with a pod using a gpu, that gpu is selected", t, func() {
+		pod := getLabeledPod("pod1", nil)
+		pod.Annotations[cardAffinityAnnotationName] = "job=train"
+		nodeResourcesUsed := nodeResources{"card0": resourceMap{}, "card1": resourceMap{"gpu.intel.com/i915": 1}}
+
+		cards, _, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
+			node, pod, nodeResourcesUsed, gpuMap)
+
+		So(err, ShouldBeNil)
+		So(cards, ShouldResemble, []string{"card1"})
+	})
+
+	Convey("When the pod has anti-affinity with the pods of every gpu it fits, it doesn't fit", t, func() {
+		pod := getLabeledPod("pod1", nil)
+		pod.Annotations[cardAntiAffinityAnnotationName] = "job=train"
+		pod.Annotations[denylistAnnotationName] = "card0"
+		nodeResourcesUsed := nodeResources{"card0": resourceMap{}, "card1": resourceMap{"gpu.intel.com/i915": 1}}
+
+		_, _, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
+			node, pod, nodeResourcesUsed, gpuMap)
+
+		var failure *fitFailure
+		So(errors.As(err, &failure), ShouldBeTrue)
+		So(failure.gpus["card1"], ShouldEqual, "card anti-affinity with pod default/pod2")
+	})
+
+	iCache = origCacheAPI
+}
+
+func TestAdjustCardPods(t *testing.T) {
+	c := getDummyCache()
+	pod := getLabeledPod("pod1", nil)
+
+	Convey("When a pod is added and removed, the pods of its cards follow", t, func() {
+		err := c.adjustPodResourcesL(pod, true, "card0", "", "node1")
+		So(err, ShouldBeNil)
+		So(c.getNodeCardPods("node1"), ShouldResemble, cardPods{"card0": {getKey(pod): pod}})
+
+		err = c.adjustPodResourcesL(pod, false, "card0", "", "node1")
+		So(err, ShouldBeNil)
+		So(c.getNodeCardPods("node1"), ShouldResemble, cardPods{})
+	})
+}
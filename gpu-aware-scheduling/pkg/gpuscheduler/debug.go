@@ -0,0 +1,231 @@
+package gpuscheduler
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	debugNodesPath     = "/debug/nodes"
+	debugPlacementPath = "/debug/placement"
+)
+
+// cardDebugStatus is the allocation state of a single gpu as shown in the debug API.
+type cardDebugStatus struct {
+	Used             resourceMap `json:"used"`
+	Capacity         resourceMap `json:"capacity"`
+	UsedTiles        []int       `json:"usedTiles"`
+	Disabled         bool        `json:"disabled"`
+	Descheduled      bool        `json:"descheduled"`
+	DisabledTiles    []int       `json:"disabledTiles"`
+	DescheduledTiles []int       `json:"descheduledTiles"`
+}
+
+// nodeDebugStatus is the allocation state of the gpus of a node as shown in the debug API.
+type nodeDebugStatus struct {
+	Name  string                     `json:"name"`
+	Cards map[string]cardDebugStatus `json:"cards"`
+}
+
+// placementRequest is the body of a dry-run placement request. Without node names, all gpu nodes are tried.
+type placementRequest struct {
+	Pod       v1.Pod   `json:"pod"`
+	NodeNames []string `json:"nodeNames,omitempty"`
+}
+
+// nodePlacement is the outcome of a dry-run placement into a node which the pod fits.
+type nodePlacement struct {
+	Annotations map[string]string `json:"annotations"`
+	Preferred   bool              `json:"preferred"`
+}
+
+// placementResult is the response to a dry-run placement request.
+type placementResult struct {
+	Nodes       map[string]nodePlacement `json:"nodes"`
+	FailedNodes map[string]string        `json:"failedNodes"`
+}
+
+// Routes returns the debug and metrics endpoints which are served next to the scheduler endpoints.
+func (m *GASExtender) Routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		debugNodesPath:       m.DebugNodes,
+		debugNodesPath + "/": m.DebugNodes,
+		debugPlacementPath:   m.DebugPlacement,
+		debugReconcilePath:   m.DebugReconcile,
+		metricsPath:          m.metrics.handler(),
+	}
+}
+
+// DebugNodes writes the gpu allocation state of all gpu nodes, or of the node named in the path.
+func (m *GASExtender) DebugNodes(w http.ResponseWriter, r *http.Request) {
+	klog.V(l4).Info("debug request received")
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	nodeName := strings.Trim(strings.TrimPrefix(r.URL.Path, debugNodesPath), "/")
+	if nodeName != "" {
+		node, err := m.getNodeForName(nodeName)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		m.writeResponse(w, m.debugNodeStatus(node))
+
+		return
+	}
+
+	nodes, err := iCache.FetchNodes(m.cache)
+	if err != nil {
+		klog.Errorf("cannot list nodes %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	statuses := []nodeDebugStatus{}
+
+	for _, node := range nodes {
+		if hasGPUCapacity(node) {
+			statuses = append(statuses, m.debugNodeStatus(node))
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+
+	m.writeResponse(w, statuses)
+}
+
+// debugNodeStatus collects the allocation state of the gpus of the given node.
+func (m *GASExtender) debugNodeStatus(node *v1.Node) nodeDebugStatus {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	status := nodeDebugStatus{Name: node.Name, Cards: map[string]cardDebugStatus{}}
+
+	gpuNames := getNodeGPUList(node)
+	gpuCapacities := getGPUResourceCapacities(node, gpuNames)
+	resourcesUsed := iCache.GetNodeResourceStatus(m.cache, node.Name)
+	tilesUsed := iCache.GetNodeTileStatus(m.cache, node.Name)
+	descheduledCards := calculateCardsFromDescheduleLabels(node)
+	disabledTiles, descheduledTiles, _ := createTileMapping(node.Labels)
+
+	for _, gpuName := range gpuNames {
+		used := resourcesUsed[gpuName]
+		if used == nil {
+			used = resourceMap{}
+		}
+
+		status.Cards[gpuName] = cardDebugStatus{
+			Used:             used,
+			Capacity:         gpuCapacities[gpuName],
+			UsedTiles:        sortedTiles(tilesUsed[gpuName]),
+			Disabled:         isGPUDisabled(gpuName, node),
+			Descheduled:      containsString(descheduledCards, gpuName),
+			DisabledTiles:    sortedTiles(disabledTiles[gpuName]),
+			DescheduledTiles: sortedTiles(descheduledTiles[gpuName]),
+		}
+	}
+
+	return status
+}
+
+// sortedTiles returns the tiles sorted, and an empty slice instead of nil, so that they encode as a json list.
+func sortedTiles(tiles []int) []int {
+	sorted := append([]int{}, tiles...)
+	sort.Ints(sorted)
+
+	return sorted
+}
+
+// DebugPlacement tells where the pod of the request would fit and which cards and tiles it would get,
+// without reserving any resources or binding the pod.
+func (m *GASExtender) DebugPlacement(w http.ResponseWriter, r *http.Request) {
+	klog.V(l4).Info("placement request received")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	request := placementRequest{}
+	if err := m.decodeRequest(&request, r); err != nil {
+		klog.Errorf("cannot decode request %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	nodes := []*v1.Node{}
+	result := placementResult{Nodes: map[string]nodePlacement{}, FailedNodes: map[string]string{}}
+
+	if len(request.NodeNames) == 0 {
+		allNodes, err := iCache.FetchNodes(m.cache)
+		if err != nil {
+			klog.Errorf("cannot list nodes %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		for _, node := range allNodes {
+			if hasGPUCapacity(node) {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+
+	for _, nodeName := range request.NodeNames {
+		node, err := m.getNodeForName(nodeName)
+		if err != nil {
+			result.FailedNodes[nodeName] = nodeUnreadableReason
+
+			continue
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	// the cache API hands out copies of the node resources, so fitting the pod leaves the cache untouched
+	for _, node := range nodes {
+		fit, err := m.fitPodToNode(&request.Pod, node)
+		if err != nil {
+			result.FailedNodes[node.Name] = fitFailureReason(err)
+
+			continue
+		}
+
+		annotations := map[string]string{}
+
+		annotation, tileAnnotation := m.convertNodeCardsToAnnotations(&request.Pod, node,
+			fit.containerCards, fit.initContainerCards)
+		if annotation != "" {
+			annotations[cardAnnotationName] = annotation
+		}
+
+		if tileAnnotation != "" {
+			annotations[tileAnnotationName] = tileAnnotation
+		}
+
+		result.Nodes[node.Name] = nodePlacement{Annotations: annotations, Preferred: fit.preferred}
+	}
+
+	m.writeResponse(w, result)
+}
@@ -0,0 +1,170 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+)
+
+func debugRequest(gas *GASExtender, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	request, err := http.NewRequestWithContext(context.Background(), method, "http://foo"+path, nil)
+	So(err, ShouldBeNil)
+	gas.DebugNodes(w, request)
+
+	return w
+}
+
+func TestDebugNodes(t *testing.T) {
+	gas := getEmptyExtender()
+	mockCache := MockCacheAPI{}
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	node := getMockNode(2, 2, "card0", "card1")
+	node.Name = "node1"
+	node.Labels["gpu.intel.com/cards"] = "card0.card1"
+	node.Labels["telemetry.aware.scheduling.foo/gas-disable-card1"] = "true"
+	node.Labels["telemetry.aware.scheduling.foo/gas-deschedule-pods-card0"] = "true"
+	node.Labels["telemetry.aware.scheduling.foo/gas-tile-disable-card0_gt1"] = "true"
+
+	setupMocks := func() {
+		mockCache.On("GetNodeResourceStatus", mock.Anything, "node1").Return(
+			nodeResources{"card0": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/tiles": 1}}).Once()
+		mockCache.On("GetNodeTileStatus", mock.Anything, "node1").Return(nodeTiles{"card0": []int{0}}).Once()
+	}
+
+	Convey("When a node is requested, its gpu state is returned", t, func() {
+		mockCache.On("FetchNode", mock.Anything, "node1").Return(node, nil).Once()
+		setupMocks()
+		w := debugRequest(gas, http.MethodGet, "/debug/nodes/node1")
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		status := nodeDebugStatus{}
+		So(json.NewDecoder(w.Body).Decode(&status), ShouldBeNil)
+		So(status.Name, ShouldEqual, "node1")
+		So(status.Cards["card0"], ShouldResemble, cardDebugStatus{
+			Used:             resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/tiles": 1},
+			Capacity:         resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 1},
+			UsedTiles:        []int{0},
+			Descheduled:      true,
+			DisabledTiles:    []int{1},
+			DescheduledTiles: []int{},
+		})
+		So(status.Cards["card1"].Disabled, ShouldBeTrue)
+		So(status.Cards["card1"].Used, ShouldResemble, resourceMap{})
+	})
+
+	Convey("When an unknown node is requested, not found is returned", t, func() {
+		mockCache.On("FetchNode", mock.Anything, "node2").Return(nil, errMock).Once()
+		w := debugRequest(gas, http.MethodGet, "/debug/nodes/node2")
+		So(w.Code, ShouldEqual, http.StatusNotFound)
+	})
+
+	Convey("When all nodes are requested, only the gpu nodes are returned", t, func() {
+		mockCache.On("FetchNodes", mock.Anything).Return([]*v1.Node{node, {}}, nil).Once()
+		setupMocks()
+		w := debugRequest(gas, http.MethodGet, "/debug/nodes")
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		statuses := []nodeDebugStatus{}
+		So(json.NewDecoder(w.Body).Decode(&statuses), ShouldBeNil)
+		So(len(statuses), ShouldEqual, 1)
+		So(statuses[0].Name, ShouldEqual, "node1")
+	})
+
+	Convey("When nodes can't be listed, an error is returned", t, func() {
+		mockCache.On("FetchNodes", mock.Anything).Return(nil, errMock).Once()
+		w := debugRequest(gas, http.MethodGet, "/debug/nodes")
+		So(w.Code, ShouldEqual, http.StatusInternalServerError)
+	})
+
+	Convey("When the debug API is called with POST, it is not allowed", t, func() {
+		w := debugRequest(gas, http.MethodPost, "/debug/nodes")
+		So(w.Code, ShouldEqual, http.StatusMethodNotAllowed)
+	})
+
+	iCache = origCacheAPI
+}
+
+func placementRequestRecorder(gas *GASExtender, method string, request *placementRequest) *httptest.ResponseRecorder {
+	body, err := json.Marshal(request)
+	So(err, ShouldBeNil)
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequestWithContext(context.Background(), method, "http://foo"+debugPlacementPath,
+		bytes.NewReader(body))
+	So(err, ShouldBeNil)
+	gas.DebugPlacement(w, r)
+
+	return w
+}
+
+func TestDebugPlacement(t *testing.T) {
+	gas := getEmptyExtender()
+	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	node := getMockNode(1, 2, "card0", "card1")
+	node.Name = "node1"
+	node.Labels["gpu.intel.com/cards"] = "card0.card1"
+
+	pod := getFakePod()
+	pod.Spec = *getMockPodSpecWithTile(1)
+
+	Convey("When the pod fits a node, its would-be annotations are returned", t, func() {
+		mockCache.On("FetchNode", mock.Anything, "node1").Return(node, nil).Once()
+		mockCache.On("FetchNode", mock.Anything, "node2").Return(nil, errMock).Once()
+		mockCache.On("GetNodeResourceStatus", mock.Anything, "node1").Return(
+			nodeResources{"card0": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/tiles": 1}}).Once()
+		mockCache.On("GetNodeTileStatus", mock.Anything, "node1").Return(nodeTiles{"card0": []int{0}}).Once()
+
+		w := placementRequestRecorder(gas, http.MethodPost,
+			&placementRequest{Pod: *pod, NodeNames: []string{"node1", "node2"}})
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		result := placementResult{}
+		So(json.NewDecoder(w.Body).Decode(&result), ShouldBeNil)
+		So(result.Nodes["node1"].Annotations, ShouldResemble, map[string]string{
+			cardAnnotationName: "card1",
+			tileAnnotationName: "card1:gt0",
+		})
+		So(result.FailedNodes, ShouldResemble, map[string]string{"node2": nodeUnreadableReason})
+	})
+
+	Convey("When no node names are given, all gpu nodes are tried and failures are explained", t, func() {
+		mockCache.On("FetchNodes", mock.Anything).Return([]*v1.Node{node, {}}, nil).Once()
+		mockCache.On("GetNodeResourceStatus", mock.Anything, "node1").Return(nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/tiles": 1},
+			"card1": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/tiles": 1},
+		}).Once()
+
+		w := placementRequestRecorder(gas, http.MethodPost, &placementRequest{Pod: *pod})
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		result := placementResult{}
+		So(json.NewDecoder(w.Body).Decode(&result), ShouldBeNil)
+		So(len(result.Nodes), ShouldEqual, 0)
+		So(result.FailedNodes["node1"], ShouldStartWith, notEnoughGPUReason+": container 1 did not fit")
+	})
+
+	Convey("When the placement API is called with GET, it is not allowed", t, func() {
+		w := placementRequestRecorder(gas, http.MethodGet, &placementRequest{})
+		So(w.Code, ShouldEqual, http.StatusMethodNotAllowed)
+	})
+
+	iCache = origCacheAPI
+}
@@ -0,0 +1,70 @@
+package gpuscheduler
+
+import (
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	exclusiveAnnotationName = "gas-exclusive-gpus"
+	// gpuExclusiveResource counts the exclusive pods using a gpu in the cache. It isn't a resource of
+	// the gpus, so no pod can request it, but a gpu which has it is fully consumed.
+	gpuExclusiveResource = gpuPrefix + "exclusive"
+)
+
+// isExclusivePod returns true if the pod requests exclusive gpus with the exclusive annotation. An exclusive
+// pod only gets gpus which no other container uses, and no other container gets them after it.
+func isExclusivePod(pod *v1.Pod) bool {
+	value, ok := pod.Annotations[exclusiveAnnotationName]
+	if !ok {
+		return false
+	}
+
+	exclusive, err := strconv.ParseBool(value)
+	if err != nil {
+		klog.Warningf("pod %v has a bad %v annotation %q, ignoring it", pod.Name, exclusiveAnnotationName, value)
+
+		return false
+	}
+
+	return exclusive
+}
+
+// isGPUIdle returns true if no container uses the gpu. Every container using a gpu gets i915 from it,
+// so the other used resources, like the disabled tiles, don't make the gpu busy.
+func isGPUIdle(used resourceMap) bool {
+	return used[gpuPluginResource] <= 0 && used[gpuExclusiveResource] <= 0
+}
+
+// exclusiveGPUReason returns the reason why the gpu can't be used for the container due to the exclusive
+// use of gpus, or an empty string if it can.
+func exclusiveGPUReason(used resourceMap, exclusive bool) string {
+	if used[gpuExclusiveResource] > 0 {
+		return "used exclusively by another pod"
+	}
+
+	if exclusive && !isGPUIdle(used) {
+		return "not idle, as requested by " + exclusiveAnnotationName + " annotation"
+	}
+
+	return ""
+}
+
+// addExclusiveUsage marks each card in the usage of an exclusive pod as used exclusively.
+func addExclusiveUsage(usage nodeResources) {
+	for _, resources := range usage {
+		resources[gpuExclusiveResource] = 1
+	}
+}
+
+// consumeExclusiveGPUs raises the used resources of the gpus used exclusively to their capacity,
+// so that no other pod fits them and the scoring sees them full.
+func consumeExclusiveGPUs(resourcesUsed, gpuCapacities nodeResources) {
+	for gpuName, used := range resourcesUsed {
+		if used[gpuExclusiveResource] > 0 {
+			used.maxRM(gpuCapacities[gpuName])
+		}
+	}
+}
@@ -0,0 +1,135 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIsExclusivePod(t *testing.T) {
+	Convey("When the pod annotation is true, the pod is exclusive", t, func() {
+		for value, exclusive := range map[string]bool{"true": true, "1": true, "false": false, "yes": false} {
+			pod := getFakePod()
+			pod.Annotations[exclusiveAnnotationName] = value
+			So(isExclusivePod(pod), ShouldEqual, exclusive)
+		}
+
+		So(isExclusivePod(getFakePod()), ShouldBeFalse)
+	})
+}
+
+func TestExclusiveGPUReason(t *testing.T) {
+	Convey("When a gpu is used exclusively, no pod can use it", t, func() {
+		used := resourceMap{"gpu.intel.com/i915": 1, gpuExclusiveResource: 1}
+		So(exclusiveGPUReason(used, false), ShouldEqual, "used exclusively by another pod")
+		So(exclusiveGPUReason(used, true), ShouldEqual, "used exclusively by another pod")
+	})
+
+	Convey("When a gpu is used, only a pod which isn't exclusive can use it", t, func() {
+		used := resourceMap{"gpu.intel.com/i915": 1}
+		So(exclusiveGPUReason(used, false), ShouldEqual, "")
+		So(exclusiveGPUReason(used, true), ShouldStartWith, "not idle")
+	})
+
+	Convey("When a gpu only has disabled tiles, it is idle", t, func() {
+		So(exclusiveGPUReason(resourceMap{"gpu.intel.com/tiles": 1}, true), ShouldEqual, "")
+	})
+}
+
+func TestExclusivePodCardUsage(t *testing.T) {
+	Convey("When the pod is exclusive, its cards are marked as used exclusively", t, func() {
+		pod := getFakePod()
+		pod.Annotations[exclusiveAnnotationName] = "true"
+
+		usage, err := podCardUsage(pod, "card1")
+		So(err, ShouldBeNil)
+		So(usage, ShouldResemble, nodeResources{
+			"card1": resourceMap{"gpu.intel.com/i915": 1, gpuExclusiveResource: 1},
+		})
+	})
+}
+
+func TestFitExclusivePodToNode(t *testing.T) {
+	gas := getDummyExtender()
+	node := getMockNode(2, 2, "card0", "card1")
+	node.Labels["gpu.intel.com/cards"] = "card0.card1"
+
+	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	Convey("When the pod is exclusive, it gets an idle gpu which is then fully consumed", t, func() {
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 1},
+		}).Once()
+
+		pod := getFakePod()
+		pod.Annotations[exclusiveAnnotationName] = "true"
+
+		fit, err := gas.fitPodToNode(pod, node)
+		So(err, ShouldBeNil)
+		So(fit.containerCards, ShouldResemble, [][]string{{"card1"}})
+		So(fit.resourcesUsed["card1"], ShouldResemble, resourceMap{
+			"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 1, gpuExclusiveResource: 1,
+		})
+	})
+
+	Convey("When a gpu is used exclusively, other pods don't fit it", t, func() {
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 1, gpuExclusiveResource: 1},
+			"card1": resourceMap{"gpu.intel.com/i915": 2},
+		}).Once()
+
+		_, err := gas.fitPodToNode(getFakePod(), node)
+
+		var failure *fitFailure
+		So(errors.As(err, &failure), ShouldBeTrue)
+		So(failure.gpus["card0"], ShouldEqual, "used exclusively by another pod")
+	})
+
+	Convey("When the exclusive pod only allows a busy gpu, it doesn't fit", t, func() {
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 1},
+		}).Once()
+
+		pod := getFakePod()
+		pod.Annotations[exclusiveAnnotationName] = "true"
+		pod.Annotations[allowlistAnnotationName] = "card0"
+
+		_, err := gas.fitPodToNode(pod, node)
+
+		var failure *fitFailure
+		So(errors.As(err, &failure), ShouldBeTrue)
+		So(failure.gpus["card0"], ShouldStartWith, "not idle")
+		So(failure.gpus["card1"], ShouldEqual, "not in gas-allow annotation")
+	})
+
+	Convey("When the idle gpu is disabled with its PCI group, the exclusive pod doesn't fit", t, func() {
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 1},
+		}).Once()
+
+		groupNode := getMockNode(2, 2, "card0", "card1")
+		groupNode.Labels["gpu.intel.com/cards"] = "card0.card1"
+		groupNode.Labels[pciGroupLabel] = "0.1"
+		groupNode.Labels["telemetry.aware.scheduling.foo/gas-disable-card0"] = pciGroupValue
+
+		pod := getFakePod()
+		pod.Annotations[exclusiveAnnotationName] = "true"
+
+		_, err := gas.fitPodToNode(pod, groupNode)
+
+		var failure *fitFailure
+		So(errors.As(err, &failure), ShouldBeTrue)
+		So(failure.gpus["card1"], ShouldStartWith, "disabled by PCI group label")
+	})
+
+	iCache = origCacheAPI
+}
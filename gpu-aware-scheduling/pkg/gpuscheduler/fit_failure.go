@@ -0,0 +1,111 @@
+package gpuscheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	nodeUnreadableReason = "Couldn't retrieve node's information"
+	notEnoughGPUReason   = "Not enough GPU-resources for deployment"
+)
+
+// fitFailure explains why the gpu requests of a pod do not fit into a node. It is an errWontFit,
+// so bind keeps reporting the plain error, while filter can tell the details to the user.
+type fitFailure struct {
+	// reason is set when the failure is about the whole node, e.g. the node has no gpus
+	reason string
+	// container is the number of the container which did not fit, starting from 1
+	container int
+	// initContainer is true if the container which did not fit is an init container
+	initContainer bool
+	// gpus has the reason for skipping each gpu of the node, for the container which did not fit
+	gpus map[string]string
+}
+
+func (f *fitFailure) Error() string {
+	return errWontFit.Error()
+}
+
+func (f *fitFailure) Unwrap() error {
+	return errWontFit
+}
+
+// describe returns the failure in a human readable form, with the gpus in name order.
+func (f *fitFailure) describe() string {
+	if f.reason != "" {
+		return f.reason
+	}
+
+	gpuNames := make([]string, 0, len(f.gpus))
+	for gpuName := range f.gpus {
+		gpuNames = append(gpuNames, gpuName)
+	}
+
+	sort.Strings(gpuNames)
+
+	gpuReasons := make([]string, 0, len(gpuNames))
+	for _, gpuName := range gpuNames {
+		gpuReasons = append(gpuReasons, gpuName+": "+f.gpus[gpuName])
+	}
+
+	containerKind := "container"
+	if f.initContainer {
+		containerKind = "init container"
+	}
+
+	description := fmt.Sprintf("%s %d did not fit", containerKind, f.container)
+	if len(gpuReasons) > 0 {
+		description += ": " + strings.Join(gpuReasons, "; ")
+	}
+
+	return description
+}
+
+// fitFailureReason returns the reason given to the user for a pod not fitting a node with the given error.
+func fitFailureReason(err error) string {
+	var failure *fitFailure
+	if errors.As(err, &failure) {
+		return notEnoughGPUReason + ": " + failure.describe()
+	}
+
+	return notEnoughGPUReason
+}
+
+// resourceShortage tells which of the needed resources don't fit based on capacity and used
+// resources, and by how much. It is the explaining counterpart of checkResourceCapacity.
+func resourceShortage(neededResources, capacity, used resourceMap) string {
+	resNames := make([]string, 0, len(neededResources))
+	for resName := range neededResources {
+		resNames = append(resNames, resName)
+	}
+
+	sort.Strings(resNames)
+
+	shortages := []string{}
+
+	for _, resName := range resNames {
+		resNeed := neededResources[resName]
+		shortName := strings.TrimPrefix(resName, gpuPrefix)
+
+		resCapacity, ok := capacity[resName]
+		if !ok || resCapacity <= 0 {
+			shortages = append(shortages, "no "+shortName+" capacity")
+
+			continue
+		}
+
+		if free := resCapacity - used[resName]; free < resNeed {
+			shortages = append(shortages, fmt.Sprintf("%s short by %d (need %d, free %d)",
+				shortName, resNeed-free, resNeed, free))
+		}
+	}
+
+	if len(shortages) == 0 {
+		return "invalid resource amounts"
+	}
+
+	return strings.Join(shortages, ", ")
+}
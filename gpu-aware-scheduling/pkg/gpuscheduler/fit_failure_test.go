@@ -0,0 +1,71 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/intel/platform-aware-scheduling/extender"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFitFailure(t *testing.T) {
+	Convey("When a fit fails, it is still a plain will not fit error", t, func() {
+		var err error = &fitFailure{reason: "node has no GPUs"}
+		So(errors.Is(err, errWontFit), ShouldBeTrue)
+		So(err.Error(), ShouldEqual, errWontFit.Error())
+	})
+
+	Convey("When a container fails to fit, the gpu reasons are described in name order", t, func() {
+		failure := fitFailure{container: 2, gpus: map[string]string{"card1": "bar", "card0": "foo"}}
+		So(failure.describe(), ShouldEqual, "container 2 did not fit: card0: foo; card1: bar")
+	})
+
+	Convey("When an init container fails to fit, it is told to be an init container", t, func() {
+		failure := fitFailure{container: 1, initContainer: true, gpus: map[string]string{"card0": "foo"}}
+		So(failure.describe(), ShouldEqual, "init container 1 did not fit: card0: foo")
+	})
+
+	Convey("When a node fails to fit, the node reason is described", t, func() {
+		failure := fitFailure{reason: "node has no GPUs"}
+		So(failure.describe(), ShouldEqual, "node has no GPUs")
+	})
+}
+
+func TestResourceShortage(t *testing.T) {
+	Convey("When resources are short, the missing amounts are told", t, func() {
+		need := resourceMap{"gpu.intel.com/millicores": 500, "gpu.intel.com/memory.max": 100, "gpu.intel.com/tiles": 1}
+		capacity := resourceMap{"gpu.intel.com/millicores": 1000, "gpu.intel.com/memory.max": 1000}
+		used := resourceMap{"gpu.intel.com/millicores": 800}
+		So(resourceShortage(need, capacity, used), ShouldEqual,
+			"millicores short by 300 (need 500, free 200), no tiles capacity")
+	})
+}
+
+func TestFilterFailureReasons(t *testing.T) {
+	gas := getEmptyExtender()
+	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	Convey("When no card fits, the reason of each card is in the failed nodes", t, func() {
+		node := getMockNode(1, 0, "card0", "card1")
+		node.Labels["gpu.intel.com/cards"] = "card0.card1"
+		node.Labels["telemetry.aware.scheduling.foo/gas-disable-card1"] = trueValueString
+		mockCache.On("FetchNode", mock.Anything, "node1").Return(node, nil).Once()
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(
+			nodeResources{"card0": resourceMap{"gpu.intel.com/i915": 1}}).Once()
+
+		result := gas.filterNodes(&extender.Args{Pod: *getFakePod(), NodeNames: &[]string{"node1"}})
+		So(result.Error, ShouldEqual, "")
+		So(result.FailedNodes["node1"], ShouldEqual, notEnoughGPUReason+": container 1 did not fit: "+
+			"card0: i915 short by 1 (need 1, free 0); card1: disabled by label gas-disable-card1")
+	})
+
+	iCache = origCacheAPI
+}
@@ -0,0 +1,223 @@
+package gpuscheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	gpuSelectorAnnotationName = "gas-gpu-selector"
+	selectorExists            = ""
+	selectorNotExists         = "!"
+	selectorEquals            = "="
+	selectorNotEquals         = "!="
+	selectorGreaterThan       = ">"
+	selectorGreaterOrEqual    = ">="
+	selectorLessThan          = "<"
+	selectorLessOrEqual       = "<="
+	selectorOperatorChars     = "!=<>"
+)
+
+// Errors.
+var (
+	errBadSelector = errors.New("bad gpu selector")
+)
+
+// selectorOperators has the operators of gpu selector requirements, the longer ones first so that
+// e.g. ">=" isn't taken for ">".
+//
+//nolint: gochecknoglobals // read-only table of the operators
+var selectorOperators = []string{
+	selectorNotEquals, selectorGreaterOrEqual, selectorLessOrEqual, selectorEquals + selectorEquals,
+	selectorEquals, selectorGreaterThan, selectorLessThan,
+}
+
+// gpuSelectorRequirement is a single requirement of a gpu selector, like "memory.max>=8Gi".
+type gpuSelectorRequirement struct {
+	key      string
+	operator string
+	value    string
+}
+
+// gpuSelector selects the gpus whose attributes meet all of its requirements.
+type gpuSelector []gpuSelectorRequirement
+
+// podGPUSelector returns the gpu selector in the annotation of the pod. A pod without the annotation
+// gets an empty selector, which selects every gpu.
+func podGPUSelector(pod *v1.Pod) (gpuSelector, error) {
+	annotation, ok := pod.Annotations[gpuSelectorAnnotationName]
+	if !ok {
+		return gpuSelector{}, nil
+	}
+
+	return parseGPUSelector(annotation)
+}
+
+// parseGPUSelector parses a comma separated list of requirements. A requirement is an attribute name,
+// an operator and a value, e.g. "model=Flex170" or "memory.max>=8Gi". The operators are =, == and !=,
+// which compare the values as quantities if both parse as such and as strings otherwise, and >, >=, <
+// and <=, which compare the values as quantities. A bare attribute name requires the attribute to exist,
+// and a name prefixed with ! requires it not to exist.
+func parseGPUSelector(selector string) (gpuSelector, error) {
+	requirements := gpuSelector{}
+
+	for _, item := range strings.Split(selector, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		requirement, err := parseGPUSelectorRequirement(item)
+		if err != nil {
+			return nil, err
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements, nil
+}
+
+// parseGPUSelectorRequirement parses a single requirement of a gpu selector.
+func parseGPUSelectorRequirement(item string) (gpuSelectorRequirement, error) {
+	index := strings.IndexAny(item, selectorOperatorChars)
+
+	switch {
+	case index < 0:
+		return gpuSelectorRequirement{key: item, operator: selectorExists}, nil
+	case index == 0 && len(item) > 1 && strings.HasPrefix(item, selectorNotExists) &&
+		!strings.ContainsAny(item[1:], selectorOperatorChars):
+		return gpuSelectorRequirement{key: item[1:], operator: selectorNotExists}, nil
+	case index == 0:
+		return gpuSelectorRequirement{}, fmt.Errorf("%w: no attribute in %q", errBadSelector, item)
+	}
+
+	requirement := gpuSelectorRequirement{key: strings.TrimSpace(item[:index])}
+	rest := item[index:]
+
+	for _, operator := range selectorOperators {
+		if strings.HasPrefix(rest, operator) {
+			requirement.operator = operator
+			requirement.value = strings.TrimSpace(rest[len(operator):])
+
+			break
+		}
+	}
+
+	if requirement.operator == selectorEquals+selectorEquals {
+		requirement.operator = selectorEquals
+	}
+
+	if requirement.operator == "" || requirement.value == "" ||
+		strings.ContainsAny(requirement.value, selectorOperatorChars) {
+		return gpuSelectorRequirement{}, fmt.Errorf("%w: bad operator or value in %q", errBadSelector, item)
+	}
+
+	if requirement.ordering() {
+		if _, err := resource.ParseQuantity(requirement.value); err != nil {
+			return gpuSelectorRequirement{}, fmt.Errorf("%w: %q is not a quantity", errBadSelector, requirement.value)
+		}
+	}
+
+	return requirement, nil
+}
+
+// ordering returns true if the requirement compares the order of quantities.
+func (r gpuSelectorRequirement) ordering() bool {
+	switch r.operator {
+	case selectorGreaterThan, selectorGreaterOrEqual, selectorLessThan, selectorLessOrEqual:
+		return true
+	}
+
+	return false
+}
+
+// matches returns true if the given attributes meet the requirement.
+func (r gpuSelectorRequirement) matches(attributes map[string]string) bool {
+	value, ok := attributes[r.key]
+
+	switch r.operator {
+	case selectorExists:
+		return ok
+	case selectorNotExists:
+		return !ok
+	case selectorEquals:
+		return ok && selectorValuesEqual(value, r.value)
+	case selectorNotEquals:
+		return !ok || !selectorValuesEqual(value, r.value)
+	}
+
+	if !ok {
+		return false
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return false
+	}
+
+	cmp := quantity.Cmp(resource.MustParse(r.value))
+
+	switch r.operator {
+	case selectorGreaterThan:
+		return cmp > 0
+	case selectorGreaterOrEqual:
+		return cmp >= 0
+	case selectorLessThan:
+		return cmp < 0
+	case selectorLessOrEqual:
+		return cmp <= 0
+	}
+
+	return false
+}
+
+// selectorValuesEqual compares the values as quantities if both parse as such, e.g. "16Gi" equals
+// "17179869184", and as strings otherwise.
+func selectorValuesEqual(a, b string) bool {
+	quantityA, errA := resource.ParseQuantity(a)
+	quantityB, errB := resource.ParseQuantity(b)
+
+	if errA == nil && errB == nil {
+		return quantityA.Cmp(quantityB) == 0
+	}
+
+	return a == b
+}
+
+// matches returns true if the given attributes meet all the requirements of the selector.
+func (s gpuSelector) matches(attributes map[string]string) bool {
+	for _, requirement := range s {
+		if !requirement.matches(attributes) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// gpuAttributes returns the attributes of the gpu for the gpu selectors. They are the node labels named
+// after the gpu, like "gpu.intel.com/card0.model", without the prefix, e.g. "model". The resource
+// capacities of the gpu are attributes too, unless the labels tell otherwise.
+func gpuAttributes(node *v1.Node, gpuName string, gpuCapacity resourceMap) map[string]string {
+	attributes := map[string]string{}
+
+	for resName, value := range gpuCapacity {
+		attributes[strings.TrimPrefix(resName, gpuPrefix)] = strconv.FormatInt(value, base10)
+	}
+
+	labelPrefix := gpuPrefix + gpuName + "."
+
+	for label, value := range node.Labels {
+		if strings.HasPrefix(label, labelPrefix) {
+			attributes[label[len(labelPrefix):]] = value
+		}
+	}
+
+	return attributes
+}
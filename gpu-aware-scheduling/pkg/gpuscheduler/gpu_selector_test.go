@@ -0,0 +1,119 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseGPUSelector(t *testing.T) {
+	Convey("When parsing a selector, each requirement gets its operator", t, func() {
+		selector, err := parseGPUSelector("model==Flex170, memory.max>=8Gi,!integrated,tiles,millicores!=100")
+		So(err, ShouldBeNil)
+		So(selector, ShouldResemble, gpuSelector{
+			{key: "model", operator: selectorEquals, value: "Flex170"},
+			{key: "memory.max", operator: selectorGreaterOrEqual, value: "8Gi"},
+			{key: "integrated", operator: selectorNotExists},
+			{key: "tiles", operator: selectorExists},
+			{key: "millicores", operator: selectorNotEquals, value: "100"},
+		})
+	})
+
+	Convey("When parsing bad selectors, an error is returned", t, func() {
+		for _, selector := range []string{"=Flex170", "model=", "memory.max>lots", "!", "model=>1"} {
+			_, err := parseGPUSelector(selector)
+			So(errors.Is(err, errBadSelector), ShouldBeTrue)
+		}
+	})
+}
+
+func TestGPUSelectorMatches(t *testing.T) {
+	attributes := map[string]string{"model": "Flex170", "memory.max": "16Gi", "millicores": "1000"}
+
+	Convey("When all requirements are met, the selector matches", t, func() {
+		selector, err := parseGPUSelector("model=Flex170,memory.max>=8Gi,memory.max=17179869184,!integrated")
+		So(err, ShouldBeNil)
+		So(selector.matches(attributes), ShouldBeTrue)
+	})
+
+	Convey("When a requirement isn't met, the selector doesn't match", t, func() {
+		for _, s := range []string{"model=Flex140", "memory.max>16Gi", "millicores<1000", "integrated", "model>1"} {
+			selector, err := parseGPUSelector(s)
+			So(err, ShouldBeNil)
+			So(selector.matches(attributes), ShouldBeFalse)
+		}
+	})
+
+	Convey("When a not equals requirement has no attribute, the selector matches", t, func() {
+		selector, err := parseGPUSelector("integrated!=true")
+		So(err, ShouldBeNil)
+		So(selector.matches(attributes), ShouldBeTrue)
+	})
+}
+
+func TestGPUAttributes(t *testing.T) {
+	node := getMockNode(1, 1, "card1", "card10")
+	node.Labels["gpu.intel.com/card1.model"] = "Flex170"
+	node.Labels["gpu.intel.com/card1.memory.max"] = "16Gi"
+	node.Labels["gpu.intel.com/card10.model"] = "Flex140"
+
+	Convey("When a gpu has labels, they override its capacities", t, func() {
+		attributes := gpuAttributes(node, "card1",
+			resourceMap{"gpu.intel.com/memory.max": 4096, "gpu.intel.com/millicores": 1000})
+		So(attributes, ShouldResemble, map[string]string{
+			"model": "Flex170", "memory.max": "16Gi", "millicores": "1000",
+		})
+	})
+}
+
+func TestGPUSelectorCardsForContainerGPURequest(t *testing.T) {
+	gas := getEmptyExtender()
+	node := getMockNode(1, 1, "card0", "card1")
+	node.Labels["gpu.intel.com/card1.model"] = "Flex170"
+	pod := getFakePod()
+
+	containerRequest := resourceMap{"gpu.intel.com/i915": 1}
+	gpuCapacities := nodeResources{"card0": resourceMap{"gpu.intel.com/i915": 1},
+		"card1": resourceMap{"gpu.intel.com/i915": 1}}
+	gpuMap := map[string]bool{"card0": true, "card1": true}
+
+	Convey("When the pod selects a gpu model, only the gpus of the model are used", t, func() {
+		pod.Annotations[gpuSelectorAnnotationName] = "model=Flex170"
+		nodeResourcesUsed := nodeResources{"card0": resourceMap{}, "card1": resourceMap{}}
+
+		cards, _, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
+			node, pod, nodeResourcesUsed, gpuMap)
+
+		So(err, ShouldBeNil)
+		So(cards, ShouldResemble, []string{"card1"})
+	})
+
+	Convey("When no gpu matches the selector, the pod doesn't fit", t, func() {
+		pod.Annotations[gpuSelectorAnnotationName] = "model=Flex140"
+		nodeResourcesUsed := nodeResources{"card0": resourceMap{}, "card1": resourceMap{}}
+
+		_, _, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
+			node, pod, nodeResourcesUsed, gpuMap)
+
+		var failure *fitFailure
+		So(errors.As(err, &failure), ShouldBeTrue)
+		So(failure.gpus["card1"], ShouldEqual, "not selected by gas-gpu-selector annotation")
+	})
+
+	Convey("When the selector is bad, the pod doesn't fit", t, func() {
+		pod.Annotations[gpuSelectorAnnotationName] = "model>Flex140"
+		nodeResourcesUsed := nodeResources{"card0": resourceMap{}, "card1": resourceMap{}}
+
+		_, _, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
+			node, pod, nodeResourcesUsed, gpuMap)
+
+		var failure *fitFailure
+		So(errors.As(err, &failure), ShouldBeTrue)
+		So(failure.reason, ShouldStartWith, "bad gas-gpu-selector annotation")
+	})
+}
@@ -0,0 +1,183 @@
+package gpuscheduler
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	sidecarAnnotationName  = "gas-sidecar-containers"
+	containerDelimiter     = "|"
+	initContainerDelimiter = ";"
+	cardDelimiter          = ","
+)
+
+// initContainerRequests returns the gpu resource requests of the init containers of the pod.
+func initContainerRequests(pod *v1.Pod) []resourceMap {
+	return resourceRequests(pod.Spec.InitContainers)
+}
+
+// hasInitContainerGPURequests returns true if any of the init containers of the pod requests gpu resources.
+func hasInitContainerGPURequests(pod *v1.Pod) bool {
+	for _, request := range initContainerRequests(pod) {
+		if len(request) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sidecarContainers returns a flag for each init container of the pod, which is true if the init container
+// is a sidecar which keeps running alongside the containers. The sidecars are named in the sidecar annotation
+// of the pod as a comma separated list, since the restartPolicy of the containers isn't known to the
+// Kubernetes API version GAS is built with.
+func sidecarContainers(pod *v1.Pod) []bool {
+	sidecars := make([]bool, len(pod.Spec.InitContainers))
+
+	annotation, ok := pod.Annotations[sidecarAnnotationName]
+	if !ok {
+		return sidecars
+	}
+
+	names := strings.Split(annotation, ",")
+
+	for i, container := range pod.Spec.InitContainers {
+		sidecars[i] = containsString(names, container.Name)
+	}
+
+	return sidecars
+}
+
+// splitContainerAnnotation splits a card or tile annotation to the parts of the init containers and
+// the containers. The init container parts come first, separated from the container parts with
+// a semicolon, e.g. "card0;card1|card2". An annotation without the init container parts, which is
+// the case if no init container uses gpus, returns nil init container parts.
+func splitContainerAnnotation(annotation string) (initParts, parts []string) {
+	if index := strings.Index(annotation, initContainerDelimiter); index >= 0 {
+		initParts = strings.Split(annotation[:index], containerDelimiter)
+		annotation = annotation[index+len(initContainerDelimiter):]
+	}
+
+	return initParts, strings.Split(annotation, containerDelimiter)
+}
+
+// joinContainerAnnotation is the reverse of splitContainerAnnotation.
+func joinContainerAnnotation(initParts, parts []string) string {
+	annotation := strings.Join(parts, containerDelimiter)
+
+	if initParts != nil {
+		annotation = strings.Join(initParts, containerDelimiter) + initContainerDelimiter + annotation
+	}
+
+	return annotation
+}
+
+// allContainerAnnotations returns the parts of all the containers in a card or tile annotation,
+// the init containers first.
+func allContainerAnnotations(annotation string) []string {
+	initParts, parts := splitContainerAnnotation(annotation)
+
+	return append(initParts, parts...)
+}
+
+// podCardUsage returns the resources the pod uses from each card, with the given card annotation. Like in
+// kubelet, the init containers run one at a time before the containers, each alongside the sidecars started
+// before it, and the sidecars keep running with the containers. The pod uses from each card the most it needs
+// at any of those stages. If the annotation has no init container parts, the init containers are not counted.
+// The cards of an exclusive pod are marked as used exclusively.
+func podCardUsage(pod *v1.Pod, annotation string) (nodeResources, error) {
+	initCards, containerCards := splitContainerAnnotation(annotation)
+	requests := containerRequests(pod)
+	initRequests := initContainerRequests(pod)
+
+	if len(requests) != len(containerCards) || (initCards != nil && len(initRequests) != len(initCards)) {
+		klog.Errorf("bad args, pod %v creqs %v ccards %v icreqs %v iccards %v",
+			pod.Name, requests, containerCards, initRequests, initCards)
+
+		return nil, errBadArgs
+	}
+
+	usage := nodeResources{}
+
+	for i, request := range requests {
+		if err := addContainerUsage(usage, request, containerCards[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if isExclusivePod(pod) {
+		defer addExclusiveUsage(usage)
+	}
+
+	if initCards == nil {
+		return usage, nil
+	}
+
+	sidecars := sidecarContainers(pod)
+
+	for i, request := range initRequests {
+		if sidecars[i] {
+			if err := addContainerUsage(usage, request, initCards[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// sidecarUsage has the usage of the sidecars started before each init container
+	sidecarUsage := nodeResources{}
+
+	for i, request := range initRequests {
+		if sidecars[i] {
+			if err := addContainerUsage(sidecarUsage, request, initCards[i]); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		initUsage := sidecarUsage.newCopy()
+		if err := addContainerUsage(initUsage, request, initCards[i]); err != nil {
+			return nil, err
+		}
+
+		for cardName, resources := range initUsage {
+			if _, ok := usage[cardName]; !ok {
+				usage[cardName] = resourceMap{}
+			}
+
+			usage[cardName].maxRM(resources)
+		}
+	}
+
+	return usage, nil
+}
+
+// addContainerUsage adds the resource request of a container to the usage of the given comma separated
+// cards. The request is divided evenly to the cards.
+func addContainerUsage(usage nodeResources, request resourceMap, cards string) error {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	cardNames := strings.Split(cards, cardDelimiter)
+	perCardRequest := request.newCopy()
+
+	if err := perCardRequest.divide(len(cardNames)); err != nil {
+		return err
+	}
+
+	for _, cardName := range cardNames {
+		if _, ok := usage[cardName]; !ok {
+			usage[cardName] = resourceMap{}
+		}
+
+		if err := usage[cardName].addRM(perCardRequest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
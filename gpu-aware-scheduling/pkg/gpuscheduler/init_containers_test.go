@@ -0,0 +1,169 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getGPUContainer(name, i915, memory string) v1.Container {
+	return v1.Container{
+		Name: name,
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				"gpu.intel.com/i915":       resource.MustParse(i915),
+				"gpu.intel.com/memory.max": resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func getInitContainerPod(sidecars string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{},
+		},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{
+				getGPUContainer("init", "1", "8"),
+				getGPUContainer("sidecar", "1", "2"),
+				getGPUContainer("late-init", "1", "4"),
+			},
+			Containers: []v1.Container{getGPUContainer("app", "1", "4")},
+		},
+	}
+
+	if sidecars != "" {
+		pod.Annotations[sidecarAnnotationName] = sidecars
+	}
+
+	return pod
+}
+
+func TestContainerAnnotation(t *testing.T) {
+	Convey("When an annotation has init container parts, they are split from the container parts", t, func() {
+		initParts, parts := splitContainerAnnotation("card0;card1,card2|card0")
+		So(initParts, ShouldResemble, []string{"card0"})
+		So(parts, ShouldResemble, []string{"card1,card2", "card0"})
+		So(joinContainerAnnotation(initParts, parts), ShouldEqual, "card0;card1,card2|card0")
+		So(allContainerAnnotations("card0;card1,card2|card0"), ShouldResemble, []string{"card0", "card1,card2", "card0"})
+	})
+
+	Convey("When an annotation has no init container parts, the init container parts are nil", t, func() {
+		initParts, parts := splitContainerAnnotation("card0|card1")
+		So(initParts, ShouldBeNil)
+		So(parts, ShouldResemble, []string{"card0", "card1"})
+		So(joinContainerAnnotation(initParts, parts), ShouldEqual, "card0|card1")
+	})
+}
+
+func TestSidecarContainers(t *testing.T) {
+	Convey("When the pod names sidecars, only those init containers are sidecars", t, func() {
+		So(sidecarContainers(getInitContainerPod("sidecar,missing")), ShouldResemble, []bool{false, true, false})
+		So(sidecarContainers(getInitContainerPod("")), ShouldResemble, []bool{false, false, false})
+	})
+}
+
+func TestPodCardUsage(t *testing.T) {
+	Convey("When the init containers run one at a time, the pod uses the most any of them needs", t, func() {
+		usage, err := podCardUsage(getInitContainerPod(""), "card0|card0|card0;card0")
+		So(err, ShouldBeNil)
+		So(usage, ShouldResemble, nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/memory.max": 8},
+		})
+	})
+
+	Convey("When an init container is a sidecar, it is counted with the containers", t, func() {
+		usage, err := podCardUsage(getInitContainerPod("sidecar"), "card0|card0|card0;card0")
+		So(err, ShouldBeNil)
+		So(usage, ShouldResemble, nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/memory.max": 8},
+		})
+	})
+
+	Convey("When the init containers use other cards, each card gets its own most", t, func() {
+		usage, err := podCardUsage(getInitContainerPod("sidecar"), "card1|card0|card1;card0")
+		So(err, ShouldBeNil)
+		So(usage, ShouldResemble, nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/memory.max": 6},
+			"card1": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/memory.max": 8},
+		})
+	})
+
+	Convey("When the annotation has no init container parts, the init containers are not counted", t, func() {
+		usage, err := podCardUsage(getInitContainerPod("sidecar"), "card0")
+		So(err, ShouldBeNil)
+		So(usage, ShouldResemble, nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/memory.max": 4},
+		})
+	})
+
+	Convey("When the annotation doesn't match the containers, an error is returned", t, func() {
+		_, err := podCardUsage(getInitContainerPod(""), "card0;card0")
+		So(err, ShouldEqual, errBadArgs)
+	})
+}
+
+func TestFitPodToNodeWithInitContainers(t *testing.T) {
+	gas := getEmptyExtender()
+	node := getMockNode(2, 1, "card0")
+	node.Status.Allocatable["gpu.intel.com/memory.max"] = resource.MustParse("8")
+
+	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	Convey("When the init containers fit one at a time, the pod fits", t, func() {
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(nodeResources{}).Once()
+
+		fit, err := gas.fitPodToNode(getInitContainerPod("sidecar"), node)
+		So(err, ShouldBeNil)
+		So(cardAnnotation(fit.initContainerCards, fit.containerCards), ShouldEqual, "card0|card0|card0;card0")
+		So(fit.resourcesUsed["card0"], ShouldResemble,
+			resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/memory.max": 8})
+	})
+
+	Convey("When an init container doesn't fit, the init container is told", t, func() {
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(nodeResources{
+			"card0": resourceMap{"gpu.intel.com/memory.max": 1},
+		}).Once()
+
+		_, err := gas.fitPodToNode(getInitContainerPod("sidecar"), node)
+
+		var failure *fitFailure
+		So(errors.As(err, &failure), ShouldBeTrue)
+		So(failure.container, ShouldEqual, 1)
+		So(failure.initContainer, ShouldBeTrue)
+	})
+
+	iCache = origCacheAPI
+}
+
+func TestConvertNodeCardsToAnnotationsWithInitContainers(t *testing.T) {
+	gas := getEmptyExtender()
+	node := getMockNode(2, 2, "card0")
+
+	pod := getFakePod()
+	pod.Spec.InitContainers = []v1.Container{getGPUContainer("init", "1", "1")}
+	pod.Spec.InitContainers[0].Resources.Requests["gpu.intel.com/tiles"] = resource.MustParse("2")
+	pod.Spec.Containers = []v1.Container{getGPUContainer("app", "1", "1")}
+	pod.Spec.Containers[0].Resources.Requests["gpu.intel.com/tiles"] = resource.MustParse("1")
+
+	Convey("When init containers use gpus, the annotations have init container parts, and the container "+
+		"gets its first tile from those of the init container", t, func() {
+		annotation, tileAnnotation := gas.convertNodeCardsToAnnotations(pod, node,
+			[][]string{{"card0"}}, [][]string{{"card0"}})
+		So(annotation, ShouldEqual, "card0;card0")
+		So(tileAnnotation, ShouldBeIn, []string{"card0:gt0+gt1;card0:gt0", "card0:gt1+gt0;card0:gt1"})
+	})
+}
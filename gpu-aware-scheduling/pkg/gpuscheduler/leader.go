@@ -0,0 +1,94 @@
+package gpuscheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
+)
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// Errors.
+var (
+	errNotLeader = errors.New("not the leader, binding is done by the leader replica")
+)
+
+// StartLeaderElection turns the extender into a follower which keeps its cache warm, but isn't ready
+// and rejects bind requests, so that a Service in front of the replicas sends the requests to the
+// leader only. The extender takes part in the leader election
+// over the named lease in the background, and once elected, it rebuilds its cache from the pod
+// annotations before it starts binding pods. Leadership is given up when the context is done.
+func (m *GASExtender) StartLeaderElection(ctx context.Context, namespace, name, identity string) error {
+	m.setLeading(false)
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Client:     m.clientset.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+		},
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		ReleaseOnCancel: true,
+		Name:            name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				m.setLeading(true)
+			},
+			OnStoppedLeading: func() {
+				m.setLeading(false)
+			},
+			OnNewLeader: func(leader string) {
+				klog.V(l2).Infof("gas leader is %v", leader)
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("leader election setup failed: %w", err)
+	}
+
+	// a lost leadership ends the run, after which the extender is a candidate again
+	go wait.UntilWithContext(ctx, elector.Run, retryPeriod)
+
+	return nil
+}
+
+// IsLeading returns true if the extender binds pods, i.e. it is the leader or doesn't take part in
+// a leader election.
+func (m *GASExtender) IsLeading() bool {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	return m.leading
+}
+
+// setLeading switches the extender between the leader and follower roles. A new leader first
+// rebuilds its cache from the pod annotations, as the reservations of the previous leader are unknown.
+func (m *GASExtender) setLeading(leading bool) {
+	m.rwmutex.Lock()
+	defer m.rwmutex.Unlock()
+
+	if leading && !m.leading {
+		klog.V(l1).Info("started leading, rebuilding the cache from pod annotations")
+
+		if err := iCache.RebuildL(m.cache); err != nil {
+			klog.Errorf("cache rebuild failed: %v", err)
+		}
+	} else if !leading && m.leading {
+		klog.V(l1).Info("following, bind requests will be rejected")
+	}
+
+	m.leading = leading
+}
@@ -0,0 +1,79 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/extender"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestLeading(t *testing.T) {
+	gas := getDummyExtender()
+	mockCache := MockCacheAPI{}
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	Convey("When the extender follows, bind requests are rejected", t, func() {
+		gas.setLeading(false)
+		mockCache.On("FetchPod", mock.Anything, "", "").Return(&v1.Pod{}, nil).Once()
+		result := gas.bindNode(&extender.BindingArgs{})
+		So(result.Error, ShouldEqual, errNotLeader.Error())
+	})
+
+	Convey("When the extender starts leading, the cache is rebuilt once", t, func() {
+		mockCache.On("RebuildL", mock.Anything).Return(nil).Once()
+		gas.setLeading(true)
+		gas.setLeading(true)
+		So(gas.leading, ShouldBeTrue)
+		mockCache.AssertNumberOfCalls(t, "RebuildL", 1)
+	})
+
+	Convey("When the leader election is started, the extender gets elected", t, func() {
+		mockCache.On("RebuildL", mock.Anything).Return(nil).Once()
+		ctx, cancel := context.WithCancel(context.Background())
+		So(gas.StartLeaderElection(ctx, "default", "gas", "replica1"), ShouldBeNil)
+
+		leading := false
+		for i := 0; i < 50 && !leading; i++ {
+			time.Sleep(100 * time.Millisecond)
+			gas.rwmutex.RLock()
+			leading = gas.leading
+			gas.rwmutex.RUnlock()
+		}
+		cancel()
+		So(leading, ShouldBeTrue)
+	})
+
+	iCache = origCacheAPI
+}
+
+func TestRebuild(t *testing.T) {
+	Convey("When the cache is rebuilt, only the annotated running pods are in it", t, func() {
+		c := createMockCache()
+		c.nodeStatuses["node2"] = nodeResources{"card0": resourceMap{"gpu.intel.com/i915": 1}}
+		c.podGroups["default/group"] = &podGroupReservation{}
+
+		completed := getAnnotatedPod("pod2", "node1", "card1", "")
+		completed.Status.Phase = v1.PodSucceeded
+
+		c.rebuild([]*v1.Pod{
+			getAnnotatedPod("pod1", "node1", "card0", "card0:gt0"),
+			completed,
+			getAnnotatedPod("pod3", "", "", ""),
+		})
+		So(c.nodeStatuses, ShouldResemble, map[string]nodeResources{
+			"node1": {"card0": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/tiles": 1}},
+		})
+		So(c.nodeTileStatuses["node1"]["card0"], ShouldResemble, []int{0})
+		So(len(c.annotatedPods), ShouldEqual, 1)
+		So(len(c.podGroups), ShouldEqual, 0)
+	})
+}
@@ -0,0 +1,150 @@
+package gpuscheduler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	metricsPath      = "/metrics"
+	metricsNamespace = "gas"
+	filterVerb       = "filter"
+	bindVerb         = "bind"
+	prioritizeVerb   = "prioritize"
+	successOutcome   = "success"
+	failureOutcome   = "failure"
+)
+
+// gasMetrics has the prometheus metrics of a GAS extender.
+type gasMetrics struct {
+	registry          *prometheus.Registry
+	filterRequests    *prometheus.CounterVec
+	filterFailedNodes *prometheus.CounterVec
+	bindRequests      *prometheus.CounterVec
+	duration          *prometheus.HistogramVec
+	lockWait          *prometheus.HistogramVec
+}
+
+func newGASMetrics(cache *Cache) *gasMetrics {
+	metrics := &gasMetrics{
+		registry: prometheus.NewRegistry(),
+		filterRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "filter_requests_total",
+			Help:      "Number of filter requests by outcome.",
+		}, []string{"outcome"}),
+		filterFailedNodes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "filter_failed_nodes_total",
+			Help:      "Number of nodes filtered out by reason.",
+		}, []string{"reason"}),
+		bindRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "bind_requests_total",
+			Help:      "Number of bind requests by outcome and failure reason.",
+		}, []string{"outcome", "reason"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the scheduler extender requests, including the time spent waiting for the lock.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"verb"}),
+		lockWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "lock_wait_duration_seconds",
+			Help:      "Time the scheduler extender requests spent waiting for the extender lock.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"verb"}),
+	}
+
+	metrics.registry.MustRegister(metrics.filterRequests, metrics.filterFailedNodes, metrics.bindRequests,
+		metrics.duration, metrics.lockWait, &cacheCollector{cache: cache})
+
+	return metrics
+}
+
+// handler serves the metrics in the prometheus format.
+func (g *gasMetrics) handler() http.HandlerFunc {
+	return promhttp.HandlerFor(g.registry, promhttp.HandlerOpts{}).ServeHTTP
+}
+
+// observeDuration records the duration of a request which started at the given time.
+func (g *gasMetrics) observeDuration(verb string, start time.Time) {
+	g.duration.WithLabelValues(verb).Observe(time.Since(start).Seconds())
+}
+
+// observeLockWait records the time spent waiting for the lock since the given time.
+func (g *gasMetrics) observeLockWait(verb string, start time.Time) {
+	g.lockWait.WithLabelValues(verb).Observe(time.Since(start).Seconds())
+}
+
+// countFilter records the outcome of a filter request and the reasons of the failed nodes.
+func (g *gasMetrics) countFilter(failed bool, failedNodes map[string]string) {
+	outcome := successOutcome
+	if failed {
+		outcome = failureOutcome
+	}
+
+	g.filterRequests.WithLabelValues(outcome).Inc()
+
+	for _, reason := range failedNodes {
+		g.filterFailedNodes.WithLabelValues(reason).Inc()
+	}
+}
+
+// countBind records the outcome of a bind request.
+func (g *gasMetrics) countBind(err error) {
+	if err == nil {
+		g.bindRequests.WithLabelValues(successOutcome, "").Inc()
+
+		return
+	}
+
+	g.bindRequests.WithLabelValues(failureOutcome, bindFailureReason(err)).Inc()
+}
+
+// bindFailureReason returns a metric label for the given bind error.
+func bindFailureReason(err error) string {
+	for _, knownErr := range []error{errBadUID, errWontFit, errPodGroupWaiting, errNotLeader} {
+		if errors.Is(err, knownErr) {
+			return knownErr.Error()
+		}
+	}
+
+	return "other"
+}
+
+// cacheCollector collects the gpu resources allocated in the cache, at the time of the scrape.
+type cacheCollector struct {
+	cache *Cache
+}
+
+//nolint:gochecknoglobals // read-only metric description
+var allocatedDesc = prometheus.NewDesc(metricsNamespace+"_gpu_allocated",
+	"Amount of a gpu resource allocated to pods, per node and card.", []string{"node", "card", "resource"}, nil)
+
+func (cc *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- allocatedDesc
+}
+
+func (cc *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	if cc.cache == nil {
+		return
+	}
+
+	cc.cache.rwmutex.RLock()
+	defer cc.cache.rwmutex.RUnlock()
+
+	for nodeName, resources := range cc.cache.nodeStatuses {
+		for cardName, resMap := range resources {
+			for resourceName, value := range resMap {
+				ch <- prometheus.MustNewConstMetric(allocatedDesc, prometheus.GaugeValue, float64(value),
+					nodeName, cardName, resourceName)
+			}
+		}
+	}
+}
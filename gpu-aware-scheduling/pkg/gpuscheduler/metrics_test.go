@@ -0,0 +1,93 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/extender"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestBindFailureReason(t *testing.T) {
+	Convey("When a bind fails with a known error, the error is the reason", t, func() {
+		So(bindFailureReason(fmt.Errorf("wrapped: %w", errWontFit)), ShouldEqual, errWontFit.Error())
+		So(bindFailureReason(errBadUID), ShouldEqual, errBadUID.Error())
+	})
+
+	Convey("When a bind fails with an unknown error, the reason is other", t, func() {
+		So(bindFailureReason(errMock), ShouldEqual, "other")
+	})
+}
+
+func TestGASMetrics(t *testing.T) {
+	Convey("When filter requests are counted, the failed nodes are counted by reason", t, func() {
+		metrics := newGASMetrics(nil)
+		metrics.countFilter(false, map[string]string{"node1": "foo", "node2": "foo", "node3": "bar"})
+		metrics.countFilter(true, nil)
+		So(testutil.ToFloat64(metrics.filterRequests.WithLabelValues(successOutcome)), ShouldEqual, 1)
+		So(testutil.ToFloat64(metrics.filterRequests.WithLabelValues(failureOutcome)), ShouldEqual, 1)
+		So(testutil.ToFloat64(metrics.filterFailedNodes.WithLabelValues("foo")), ShouldEqual, 2)
+		So(testutil.ToFloat64(metrics.filterFailedNodes.WithLabelValues("bar")), ShouldEqual, 1)
+	})
+
+	Convey("When bind requests are counted, the failures are counted by reason", t, func() {
+		metrics := newGASMetrics(nil)
+		metrics.countBind(nil)
+		metrics.countBind(errBadUID)
+		So(testutil.ToFloat64(metrics.bindRequests.WithLabelValues(successOutcome, "")), ShouldEqual, 1)
+		So(testutil.ToFloat64(metrics.bindRequests.WithLabelValues(failureOutcome, errBadUID.Error())), ShouldEqual, 1)
+	})
+
+	Convey("When durations are observed, they are in the histograms", t, func() {
+		metrics := newGASMetrics(nil)
+		metrics.observeDuration(filterVerb, time.Now())
+		metrics.observeLockWait(filterVerb, time.Now())
+		So(testutil.CollectAndCount(metrics.duration), ShouldEqual, 1)
+		So(testutil.CollectAndCount(metrics.lockWait), ShouldEqual, 1)
+	})
+
+	Convey("When the cache has allocations, they are collected per node and card", t, func() {
+		c := getDummyCache()
+		c.nodeStatuses["node1"] = nodeResources{
+			"card0": resourceMap{"gpu.intel.com/millicores": 100, "gpu.intel.com/tiles": 1},
+			"card1": resourceMap{"gpu.intel.com/millicores": 200},
+		}
+		So(testutil.CollectAndCount(&cacheCollector{cache: c}), ShouldEqual, 3)
+		So(testutil.CollectAndCount(&cacheCollector{}), ShouldEqual, 0)
+	})
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	gas := getEmptyExtender()
+	mockCache := MockCacheAPI{}
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	Convey("When a filter request has been handled, it shows up in the metrics", t, func() {
+		mockCache.On("FetchNode", mock.Anything, "node1").Return(nil, errMock).Once()
+		gas.filterNodes(&extender.Args{Pod: v1.Pod{}, NodeNames: &[]string{"node1"}})
+
+		w := httptest.NewRecorder()
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://foo/metrics", nil)
+		So(err, ShouldBeNil)
+		gas.Routes()[metricsPath](w, request)
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(strings.Contains(w.Body.String(),
+			`gas_filter_failed_nodes_total{reason="Couldn't retrieve node's information"}`), ShouldBeTrue)
+		So(strings.Contains(w.Body.String(), `gas_lock_wait_duration_seconds_count{verb="filter"}`), ShouldBeTrue)
+	})
+
+	iCache = origCacheAPI
+}
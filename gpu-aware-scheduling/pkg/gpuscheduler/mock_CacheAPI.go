@@ -8,6 +8,8 @@
 package gpuscheduler
 
 import (
+	"time"
+
 	mock "github.com/stretchr/testify/mock"
 	kubernetes "k8s.io/client-go/kubernetes"
 
@@ -56,6 +58,29 @@ func (_m *MockCacheAPI) FetchNode(cache *Cache, nodeName string) (*v1.Node, erro
 	return r0, r1
 }
 
+// FetchNodes provides a mock function with given fields: cache
+func (_m *MockCacheAPI) FetchNodes(cache *Cache) ([]*v1.Node, error) {
+	ret := _m.Called(cache)
+
+	var r0 []*v1.Node
+	if rf, ok := ret.Get(0).(func(*Cache) []*v1.Node); ok {
+		r0 = rf(cache)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Node)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Cache) error); ok {
+		r1 = rf(cache)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FetchPod provides a mock function with given fields: cache, podNS, podName
 func (_m *MockCacheAPI) FetchPod(cache *Cache, podNS string, podName string) (*v1.Pod, error) {
 	ret := _m.Called(cache, podNS, podName)
@@ -79,6 +104,22 @@ func (_m *MockCacheAPI) FetchPod(cache *Cache, podNS string, podName string) (*v
 	return r0, r1
 }
 
+// GetNodeCardPods provides a mock function with given fields: cache, nodeName
+func (_m *MockCacheAPI) GetNodeCardPods(cache *Cache, nodeName string) cardPods {
+	ret := _m.Called(cache, nodeName)
+
+	var r0 cardPods
+	if rf, ok := ret.Get(0).(func(*Cache, string) cardPods); ok {
+		r0 = rf(cache, nodeName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cardPods)
+		}
+	}
+
+	return r0
+}
+
 // GetNodeResourceStatus provides a mock function with given fields: cache, nodeName
 func (_m *MockCacheAPI) GetNodeResourceStatus(cache *Cache, nodeName string) nodeResources {
 	ret := _m.Called(cache, nodeName)
@@ -111,6 +152,64 @@ func (_m *MockCacheAPI) GetNodeTileStatus(cache *Cache, nodeName string) nodeTil
 	return r0
 }
 
+// GetReconcileProblems provides a mock function with given fields: cache
+func (_m *MockCacheAPI) GetReconcileProblems(cache *Cache) []reconcileProblem {
+	ret := _m.Called(cache)
+
+	var r0 []reconcileProblem
+	if rf, ok := ret.Get(0).(func(*Cache) []reconcileProblem); ok {
+		r0 = rf(cache)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reconcileProblem)
+		}
+	}
+
+	return r0
+}
+
+// IsPodGroupMemberBound provides a mock function with given fields: cache, group, pod
+func (_m *MockCacheAPI) IsPodGroupMemberBound(cache *Cache, group string, pod *v1.Pod) bool {
+	ret := _m.Called(cache, group, pod)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*Cache, string, *v1.Pod) bool); ok {
+		r0 = rf(cache, group, pod)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsPodGroupMemberReserved provides a mock function with given fields: cache, group, pod
+func (_m *MockCacheAPI) IsPodGroupMemberReserved(cache *Cache, group string, pod *v1.Pod) bool {
+	ret := _m.Called(cache, group, pod)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*Cache, string, *v1.Pod) bool); ok {
+		r0 = rf(cache, group, pod)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsReady provides a mock function with given fields: cache
+func (_m *MockCacheAPI) IsReady(cache *Cache) bool {
+	ret := _m.Called(cache)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*Cache) bool); ok {
+		r0 = rf(cache)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // NewCache provides a mock function with given fields: _a0
 func (_m *MockCacheAPI) NewCache(_a0 kubernetes.Interface) *Cache {
 	ret := _m.Called(_a0)
@@ -126,3 +225,40 @@ func (_m *MockCacheAPI) NewCache(_a0 kubernetes.Interface) *Cache {
 
 	return r0
 }
+
+// RebuildL provides a mock function with given fields: cache
+func (_m *MockCacheAPI) RebuildL(cache *Cache) error {
+	ret := _m.Called(cache)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*Cache) error); ok {
+		r0 = rf(cache)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReservePodGroupMemberL provides a mock function with given fields: cache, group, size, timeout, member
+func (_m *MockCacheAPI) ReservePodGroupMemberL(cache *Cache, group string, size int, timeout time.Duration, member podGroupMember) ([]podGroupMember, error) {
+	ret := _m.Called(cache, group, size, timeout, member)
+
+	var r0 []podGroupMember
+	if rf, ok := ret.Get(0).(func(*Cache, string, int, time.Duration, podGroupMember) []podGroupMember); ok {
+		r0 = rf(cache, group, size, timeout, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]podGroupMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Cache, string, int, time.Duration, podGroupMember) error); ok {
+		r1 = rf(cache, group, size, timeout, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -20,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -83,18 +84,35 @@ type Cache struct {
 	annotatedPods         map[string]string
 	nodeStatuses          map[string]nodeResources
 	nodeTileStatuses      map[string]nodeTiles
+	nodeCardPods          map[string]cardPods
 	previousDeschedCards  map[string][]string /* node -> list of cards */
 	previousDeschedTiles  map[string][]string /* node -> list of card+tile combos "x.y" */
 	podDeschedStatuses    map[string]bool
+	podGroups             map[string]*podGroupReservation
+	reconcileProblems     []reconcileProblem
+	informersSynced       []cache.InformerSynced
 	rwmutex               sync.RWMutex
 }
 
 // Node resources = a map of resourceMaps accessed by node gpu names.
 type nodeResources map[string]resourceMap
 
+func (nr nodeResources) newCopy() nodeResources {
+	nrCopy := make(nodeResources, len(nr))
+
+	for cardName, rm := range nr {
+		nrCopy[cardName] = rm.newCopy()
+	}
+
+	return nrCopy
+}
+
 // Node tiles = map to slice of indices of used tiles (gpu name -> []int).
 type nodeTiles map[string][]int
 
+// Card pods = map of the pods using each card (gpu name -> pod key -> pod).
+type cardPods map[string]map[string]*v1.Pod
+
 const /*pod action*/ (
 	podUpdated = iota
 	podAdded
@@ -195,17 +213,41 @@ func NewCache(client kubernetes.Interface) *Cache {
 		podDeschedStatuses:    make(map[string]bool),
 		nodeStatuses:          make(map[string]nodeResources),
 		nodeTileStatuses:      make(map[string]nodeTiles),
+		nodeCardPods:          make(map[string]cardPods),
+		podGroups:             make(map[string]*podGroupReservation),
+		reconcileProblems:     []reconcileProblem{},
+		informersSynced:       []cache.InformerSynced{nodeInformer.Informer().HasSynced, podInformer.Informer().HasSynced},
 	}
 
+	// check the gpu annotations of the existing pods before the informer handlers start adding them
+	c.reconcileL()
+
 	podInformer.Informer().AddEventHandler(c.createFilteringPodResourceHandler())
 	nodeInformer.Informer().AddEventHandler(c.createFilteringNodeResourceHandler())
 
 	go func() { c.startPodWork(stopChannel) }()
 	go func() { c.startNodeWork(stopChannel) }()
+	go func() { c.startPodGroupWork(stopChannel) }()
 
 	return &c
 }
 
+// isReady returns true while the cache follows the cluster: its informers have synced and its workers
+// haven't been stopped, as they are when the extender shuts down.
+func (c *Cache) isReady() bool {
+	if c.podWorkQueue.ShuttingDown() || c.nodeWorkQueue.ShuttingDown() {
+		return false
+	}
+
+	for _, synced := range c.informersSynced {
+		if !synced() {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (c *Cache) podFilter(obj interface{}) bool {
 	var pod *v1.Pod
 
@@ -275,55 +317,36 @@ func (c *Cache) newCopyNodeStatus(nodeName string) nodeResources {
 	return nodeRes
 }
 
-// checkPodResourceAdjustment goes through all the containers and checks for errors in
+// checkPodResourceAdjustment checks the resources used by the pod from each card for errors in
 // the node resource-map arithmetics (like integer overflows). If any fail, this returns an error.
 // This must be called with the rwmutex at least read-locked.
 // set adj=true to add, false to remove resources.
-func (c *Cache) checkPodResourceAdjustment(containerRequests []resourceMap,
-	nodeName string, containerCards []string, adj bool) error {
-	if len(containerRequests) != len(containerCards) || nodeName == "" {
-		klog.Errorf("bad args, node %v pod creqs %v ccards %v", nodeName, containerRequests, containerCards)
+func (c *Cache) checkPodResourceAdjustment(usage nodeResources, nodeName string, adj bool) error {
+	if nodeName == "" {
+		klog.Errorf("bad args, node %v usage %v", nodeName, usage)
 
 		return errBadArgs
 	}
 
-	numContainers := len(containerRequests)
 	nodeRes := c.newCopyNodeStatus(nodeName)
 
 	var err error
 
-	for i := 0; i < numContainers; i++ {
-		// get slice of card names from the CSV list of container nr i
-		cardNames := strings.Split(containerCards[i], ",")
-		numCards := len(cardNames)
-
-		if numCards == 0 || len(containerCards[i]) == 0 {
-			continue
+	for cardName, request := range usage {
+		_, ok := nodeRes[cardName]
+		if !ok {
+			nodeRes[cardName] = resourceMap{}
 		}
 
-		request := containerRequests[i].newCopy()
+		if adj { // add
+			err = nodeRes[cardName].addRM(request)
+		} else {
+			err = nodeRes[cardName].subtractRM(request)
+		}
 
-		err = request.divide(numCards)
 		if err != nil {
 			return err
 		}
-
-		for _, cardName := range cardNames {
-			_, ok := nodeRes[cardName]
-			if !ok {
-				nodeRes[cardName] = resourceMap{}
-			}
-
-			if adj { // add
-				err = nodeRes[cardName].addRM(request)
-			} else {
-				err = nodeRes[cardName].subtractRM(request)
-			}
-
-			if err != nil {
-				return err
-			}
-		}
 	}
 
 	return nil
@@ -344,6 +367,41 @@ func getTileIndices(tileNames []string) []int {
 	return tileIndices
 }
 
+// adjustCardPods adds the pod to, or removes it from, the pods using the cards in its usage.
+// This must be called with rwmutex locked
+// set adj=true to add, false to remove the pod.
+func (c *Cache) adjustCardPods(pod *v1.Pod, adj bool, nodeName string, usage nodeResources) {
+	key := getKey(pod)
+
+	if !adj {
+		for cardName := range usage {
+			delete(c.nodeCardPods[nodeName][cardName], key)
+
+			if len(c.nodeCardPods[nodeName][cardName]) == 0 {
+				delete(c.nodeCardPods[nodeName], cardName)
+			}
+		}
+
+		if len(c.nodeCardPods[nodeName]) == 0 {
+			delete(c.nodeCardPods, nodeName)
+		}
+
+		return
+	}
+
+	if _, ok := c.nodeCardPods[nodeName]; !ok && len(usage) > 0 {
+		c.nodeCardPods[nodeName] = cardPods{}
+	}
+
+	for cardName := range usage {
+		if _, ok := c.nodeCardPods[nodeName][cardName]; !ok {
+			c.nodeCardPods[nodeName][cardName] = map[string]*v1.Pod{}
+		}
+
+		c.nodeCardPods[nodeName][cardName][key] = pod
+	}
+}
+
 // This must be called with rwmutex locked
 // set adj=true to add, false to remove resources.
 func (c *Cache) adjustTiles(adj bool, nodeName, tileAnnotation string) {
@@ -353,7 +411,7 @@ func (c *Cache) adjustTiles(adj bool, nodeName, tileAnnotation string) {
 		tileUsage = c.nodeTileStatuses[nodeName]
 	}
 
-	containerSplit := strings.Split(tileAnnotation, "|")
+	containerSplit := allContainerAnnotations(tileAnnotation)
 
 	numContainers := len(containerSplit)
 	for i := 0; i < numContainers; i++ {
@@ -397,53 +455,38 @@ func (c *Cache) adjustTiles(adj bool, nodeName, tileAnnotation string) {
 // This must be called with rwmutex locked
 // set adj=true to add, false to remove resources.
 func (c *Cache) adjustPodResources(pod *v1.Pod, adj bool, annotation, tileAnnotation, nodeName string) error {
-	// get slice of resource maps, one map per container
-	containerRequests := containerRequests(pod)
-
-	// get slice of card name lists, one CSV list per container
-	containerCards := strings.Split(annotation, "|")
+	// get the resources used by the pod from each card, init containers included
+	usage, err := podCardUsage(pod, annotation)
+	if err != nil {
+		return err
+	}
 
 	// we need to be atomic, either all succeed or none succeed, so check first
-	err := c.checkPodResourceAdjustment(containerRequests, nodeName, containerCards, adj)
+	err = c.checkPodResourceAdjustment(usage, nodeName, adj)
 	if err != nil {
 		return err
 	}
 
 	// now that we have checked, error checks are omitted below
-	numContainers := len(containerRequests)
-	for i := 0; i < numContainers; i++ {
-		// get slice of card names from the CSV list of container nr i
-		cardNames := strings.Split(containerCards[i], ",")
-		numCards := len(cardNames)
-
-		if numCards == 0 || len(containerCards[i]) == 0 {
-			continue
-		}
-
-		err = containerRequests[i].divide(numCards)
-		if err != nil {
-			return err
-		}
+	if _, ok := c.nodeStatuses[nodeName]; !ok && len(usage) > 0 {
+		c.nodeStatuses[nodeName] = nodeResources{}
+	}
 
-		if _, ok := c.nodeStatuses[nodeName]; !ok {
-			c.nodeStatuses[nodeName] = nodeResources{}
+	for cardName, request := range usage {
+		_, ok := c.nodeStatuses[nodeName][cardName]
+		if !ok {
+			c.nodeStatuses[nodeName][cardName] = resourceMap{}
 		}
 
-		for _, cardName := range cardNames {
-			_, ok := c.nodeStatuses[nodeName][cardName]
-			if !ok {
-				c.nodeStatuses[nodeName][cardName] = resourceMap{}
-			}
-
-			if adj { // add
-				_ = c.nodeStatuses[nodeName][cardName].addRM(containerRequests[i])
-			} else {
-				_ = c.nodeStatuses[nodeName][cardName].subtractRM(containerRequests[i])
-			}
+		if adj { // add
+			_ = c.nodeStatuses[nodeName][cardName].addRM(request)
+		} else {
+			_ = c.nodeStatuses[nodeName][cardName].subtractRM(request)
 		}
 	}
 
 	c.adjustTiles(adj, nodeName, tileAnnotation)
+	c.adjustCardPods(pod, adj, nodeName, usage)
 
 	if adj { // add
 		c.annotatedPods[getKey(pod)] = annotation
@@ -805,6 +848,16 @@ func (c *Cache) fetchNode(nodeName string) (*v1.Node, error) {
 	return node, nil
 }
 
+// this fetches all the nodes.
+func (c *Cache) fetchNodes() ([]*v1.Node, error) {
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("node list error: %w", err)
+	}
+
+	return nodes, nil
+}
+
 func (c *Cache) fetchPod(ns, name string) (*v1.Pod, error) {
 	nsLister := c.podLister.Pods(ns)
 
@@ -833,6 +886,25 @@ func (c *Cache) getNodeTileStatus(nodeName string) nodeTiles {
 	return dstNodeTiles
 }
 
+// getNodeCardPods returns a copy of the pods using each card of a node.
+func (c *Cache) getNodeCardPods(nodeName string) cardPods {
+	klog.V(l4).Infof("getNodeCardPods %v", nodeName)
+	c.rwmutex.RLock()
+	klog.V(l5).Infof("getNodeCardPods %v locked", nodeName)
+	defer c.rwmutex.RUnlock()
+
+	dstCardPods := cardPods{}
+
+	for gpuName, pods := range c.nodeCardPods[nodeName] {
+		dstCardPods[gpuName] = map[string]*v1.Pod{}
+		for key, pod := range pods {
+			dstCardPods[gpuName][key] = pod
+		}
+	}
+
+	return dstCardPods
+}
+
 // getNodeResourceStatus returns a copy of current resource status for a node (map of per card resource maps).
 func (c *Cache) getNodeResourceStatus(nodeName string) nodeResources {
 	klog.V(l4).Infof("getNodeResourceStatus %v", nodeName)
@@ -857,7 +929,7 @@ func allPodGPUs(pod *v1.Pod) map[string]bool {
 	gpus := map[string]bool{}
 
 	if annotation, ok := pod.Annotations[cardAnnotationName]; ok {
-		lists := strings.Split(annotation, "|")
+		lists := allContainerAnnotations(annotation)
 		for _, list := range lists {
 			gpuList := strings.Split(list, ",")
 			for _, gpuName := range gpuList {
@@ -1035,7 +1107,9 @@ func (c *Cache) handlePod(item podWorkQueueItem) (forget bool, err error) {
 		fallthrough
 	case podDeleted:
 		annotation, annotatedPod := c.annotatedPods[key]
-		if annotatedPod {
+		if c.releasePodGroupMember(item.pod) {
+			msg += "podDeleted, key:" + key + " pod group reservation released"
+		} else if annotatedPod {
 			msg += "podDeleted, key:" + key + " annotation:" + annotation
 			err = c.adjustPodResources(item.pod, remove, item.annotation, item.tileAnnotation, item.pod.Spec.NodeName)
 		} else {
@@ -53,9 +53,15 @@ func TestNewCache(t *testing.T) {
 var dummyCache *Cache
 
 func (c *Cache) reset() {
+	c.rwmutex.Lock()
+	defer c.rwmutex.Unlock()
+
 	c.annotatedPods = map[string]string{}
 	c.nodeStatuses = map[string]nodeResources{}
 	c.nodeTileStatuses = map[string]nodeTiles{}
+	c.nodeCardPods = map[string]cardPods{}
+	c.podGroups = map[string]*podGroupReservation{}
+	c.reconcileProblems = []reconcileProblem{}
 	c.previousDeschedCards = map[string][]string{}
 	c.previousDeschedTiles = map[string][]string{}
 	c.podDeschedStatuses = map[string]bool{}
@@ -233,6 +239,9 @@ func createMockCache() *Cache {
 		annotatedPods:         make(map[string]string),
 		nodeStatuses:          make(map[string]nodeResources),
 		nodeTileStatuses:      make(map[string]nodeTiles),
+		nodeCardPods:          make(map[string]cardPods),
+		podGroups:             make(map[string]*podGroupReservation),
+		reconcileProblems:     []reconcileProblem{},
 	}
 }
 
@@ -418,6 +427,25 @@ func TestAdjustPodResources(t *testing.T) {
 		So(len(tiles), ShouldEqual, 1)
 		So(0, ShouldBeIn, tiles)
 	})
+
+	Convey("When adjusting pod resources with an init container", t, func() {
+		initPod := pod
+		initPod.Spec.InitContainers = []v1.Container{podContainer}
+		c.nodeStatuses = make(map[string]nodeResources)
+		c.nodeTileStatuses = make(map[string]nodeTiles)
+		err := c.adjustPodResources(&initPod, true, "card1;card0", "card1:gt0;card0:gt0", "node1")
+
+		So(err, ShouldBeNil)
+		So(c.nodeStatuses["node1"]["card0"]["gpu.intel.com/i915"], ShouldEqual, 1)
+		So(c.nodeStatuses["node1"]["card1"]["gpu.intel.com/i915"], ShouldEqual, 1)
+		So(c.nodeTileStatuses["node1"]["card1"], ShouldResemble, []int{0})
+
+		err = c.adjustPodResources(&initPod, false, "card1;card0", "card1:gt0;card0:gt0", "node1")
+
+		So(err, ShouldBeNil)
+		So(c.nodeStatuses["node1"]["card1"]["gpu.intel.com/i915"], ShouldEqual, 0)
+		So(len(c.nodeTileStatuses["node1"]["card1"]), ShouldEqual, 0)
+	})
 }
 
 func TestGetTileIndices(t *testing.T) {
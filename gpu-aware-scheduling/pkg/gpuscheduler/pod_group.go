@@ -0,0 +1,277 @@
+package gpuscheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	podGroupAnnotationName     = "gas-pod-group"
+	podGroupSizeAnnotationName = "gas-pod-group-size"
+	reservationCheckInterval   = time.Second * 5
+)
+
+// Errors.
+var (
+	errPodGroupWaiting      = errors.New("waiting for the other members of the pod group")
+	errPodGroupSizeMismatch = errors.New("pod group size differs from the other members of the pod group")
+)
+
+// podGroupMember is a tentative gpu reservation of a pod which belongs to a pod group.
+type podGroupMember struct {
+	pod            *v1.Pod
+	annotation     string
+	tileAnnotation string
+	nodeName       string
+}
+
+// podGroupReservation holds the reservations of a pod group until all of its members fit.
+type podGroupReservation struct {
+	members  map[string]podGroupMember // pod key -> member
+	size     int
+	deadline time.Time
+}
+
+// podGroup returns the key and the size of the pod group the pod belongs to. The last return value
+// is false if the pod doesn't belong to a valid pod group.
+func podGroup(pod *v1.Pod) (string, int, bool) {
+	name, ok := pod.Annotations[podGroupAnnotationName]
+	if !ok || name == "" {
+		return "", 0, false
+	}
+
+	size, err := strconv.Atoi(pod.Annotations[podGroupSizeAnnotationName])
+	if err != nil || size < 1 {
+		klog.Warningf("pod %v has a bad pod group size %q, scheduling it alone",
+			pod.Name, pod.Annotations[podGroupSizeAnnotationName])
+
+		return "", 0, false
+	}
+
+	return pod.Namespace + "/" + name, size, true
+}
+
+// isPodGroupMemberReserved returns true if the pod already has a reservation in the pod group.
+func (c *Cache) isPodGroupMemberReserved(group string, pod *v1.Pod) bool {
+	c.rwmutex.RLock()
+	defer c.rwmutex.RUnlock()
+
+	reservation, ok := c.podGroups[group]
+	if !ok {
+		return false
+	}
+
+	_, ok = reservation.members[getKey(pod)]
+
+	return ok
+}
+
+// isPodGroupMemberBound returns true if the pod is a member of a completed pod group, which GAS has
+// annotated and bound, or is binding, with the resources of its reservation.
+func (c *Cache) isPodGroupMemberBound(group string, pod *v1.Pod) bool {
+	c.rwmutex.RLock()
+	defer c.rwmutex.RUnlock()
+
+	key := getKey(pod)
+
+	if reservation, ok := c.podGroups[group]; ok {
+		if _, ok := reservation.members[key]; ok {
+			return false
+		}
+	}
+
+	_, ok := c.annotatedPods[key]
+
+	return ok
+}
+
+// reservePodGroupMemberL reserves the resources of the given annotations for a pod group member.
+// When the reservation completes the pod group, the bookkeeping of the group is dropped and all the
+// members are returned, so that they can be bound. Otherwise nil is returned. The size of the group
+// is set by its first member, and a member with another size is rejected.
+// This must be called with rwmutex unlocked.
+func (c *Cache) reservePodGroupMemberL(group string, size int, timeout time.Duration,
+	member podGroupMember) ([]podGroupMember, error) {
+	klog.V(l4).Infof("reservePodGroupMemberL %v %v", group, member.pod.Name)
+	c.rwmutex.Lock()
+	klog.V(l5).Infof("reservePodGroupMemberL %v %v locked", group, member.pod.Name)
+	defer c.rwmutex.Unlock()
+
+	reservation, ok := c.podGroups[group]
+	if ok && reservation.size != size {
+		return nil, fmt.Errorf("%w: pod %v has size %v, the pod group %v has %v",
+			errPodGroupSizeMismatch, member.pod.Name, size, group, reservation.size)
+	}
+
+	err := c.adjustPodResources(member.pod, add, member.annotation, member.tileAnnotation, member.nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		reservation = &podGroupReservation{
+			members:  map[string]podGroupMember{},
+			size:     size,
+			deadline: time.Now().Add(timeout),
+		}
+		c.podGroups[group] = reservation
+	}
+
+	reservation.members[getKey(member.pod)] = member
+
+	klog.V(l3).Infof("pod group %v has %v out of %v members reserved", group, len(reservation.members), reservation.size)
+
+	if len(reservation.members) < reservation.size {
+		return nil, nil
+	}
+
+	delete(c.podGroups, group)
+
+	members := make([]podGroupMember, 0, len(reservation.members))
+	for _, member := range reservation.members {
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// releaseExpiredPodGroups releases the reservations of the pod groups which didn't complete in time.
+func (c *Cache) releaseExpiredPodGroups() {
+	c.rwmutex.Lock()
+	defer c.rwmutex.Unlock()
+
+	now := time.Now()
+
+	for group, reservation := range c.podGroups {
+		if now.Before(reservation.deadline) {
+			continue
+		}
+
+		klog.Warningf("pod group %v timed out with %v out of %v members, releasing its reservations",
+			group, len(reservation.members), reservation.size)
+
+		for _, member := range reservation.members {
+			err := c.adjustPodResources(member.pod, remove, member.annotation, member.tileAnnotation, member.nodeName)
+			if err != nil {
+				klog.Errorf("releasing pod %v of pod group %v failed: %v", member.pod.Name, group, err)
+			}
+		}
+
+		delete(c.podGroups, group)
+	}
+}
+
+// releasePodGroupMember releases the reservation of the pod, if it is a reserved member of an incomplete
+// pod group, and drops the pod from the group. It returns true if the pod had a reservation.
+// This must be called with rwmutex locked.
+func (c *Cache) releasePodGroupMember(pod *v1.Pod) bool {
+	group, _, ok := podGroup(pod)
+	if !ok {
+		return false
+	}
+
+	reservation, ok := c.podGroups[group]
+	if !ok {
+		return false
+	}
+
+	key := getKey(pod)
+
+	member, ok := reservation.members[key]
+	if !ok {
+		return false
+	}
+
+	err := c.adjustPodResources(member.pod, remove, member.annotation, member.tileAnnotation, member.nodeName)
+	if err != nil {
+		klog.Errorf("releasing pod %v of pod group %v failed: %v", member.pod.Name, group, err)
+	}
+
+	delete(reservation.members, key)
+
+	if len(reservation.members) == 0 {
+		delete(c.podGroups, group)
+	}
+
+	return true
+}
+
+// This steals the calling goroutine and blocks doing work.
+func (c *Cache) startPodGroupWork(stopChannel <-chan struct{}) {
+	defer runtime.HandleCrash()
+
+	klog.V(l2).Info("starting pod group worker")
+
+	// block calling goroutine
+	wait.Until(c.releaseExpiredPodGroups, reservationCheckInterval, stopChannel)
+
+	klog.V(l2).Info("pod group worker shutting down")
+}
+
+// reservePodGroupMember reserves the gpus for a pod group member. Once all the members of the group
+// have their reservations, all the members get annotated and bound. Until then errPodGroupWaiting is returned.
+func (m *GASExtender) reservePodGroupMember(group string, size int, member podGroupMember) error {
+	members, err := iCache.ReservePodGroupMemberL(m.cache, group, size, m.podGroupTimeout, member)
+	if err != nil {
+		return err
+	}
+
+	if members == nil {
+		return errPodGroupWaiting
+	}
+
+	klog.V(l2).Infof("pod group %v is complete, binding %v pods", group, len(members))
+
+	var podErr error
+
+	for _, groupMember := range members {
+		bindErr := m.annotateAndBindPod(groupMember)
+		if bindErr == nil {
+			continue
+		}
+
+		klog.Errorf("binding pod %v of pod group %v failed: %v", groupMember.pod.Name, group, bindErr)
+
+		// Restore resources to cache. Removing resources should not fail if adding was ok.
+		err = iCache.AdjustPodResourcesL(m.cache, groupMember.pod, remove,
+			groupMember.annotation, groupMember.tileAnnotation, groupMember.nodeName)
+		if err != nil {
+			klog.Warning("adjust pod resources failed", err.Error())
+		}
+
+		if groupMember.pod.UID == member.pod.UID {
+			podErr = bindErr
+		}
+	}
+
+	return podErr
+}
+
+// annotateAndBindPod annotates the pod with its gpu selection and binds it to the node.
+func (m *GASExtender) annotateAndBindPod(member podGroupMember) error {
+	err := m.annotatePodBind(member.annotation, member.tileAnnotation, member.pod)
+	if err != nil {
+		return err
+	}
+
+	binding := &v1.Binding{
+		ObjectMeta: metav1.ObjectMeta{Name: member.pod.Name, Namespace: member.pod.Namespace, UID: member.pod.UID},
+		Target:     v1.ObjectReference{Kind: "Node", Name: member.nodeName},
+	}
+
+	err = m.clientset.CoreV1().Pods(member.pod.Namespace).Bind(context.TODO(), binding, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("pod %s binding failed: %w", member.pod.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/extender"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getPodGroupPod(name, group, size string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Annotations: map[string]string{
+				podGroupAnnotationName:     group,
+				podGroupSizeAnnotationName: size,
+			},
+		},
+		Spec: *getMockPodSpec(),
+	}
+}
+
+func TestPodGroup(t *testing.T) {
+	Convey("When pod has a pod group annotation with a size, it belongs to the group", t, func() {
+		group, size, ok := podGroup(getPodGroupPod("foo", "bar", "2"))
+		So(ok, ShouldBeTrue)
+		So(group, ShouldEqual, "default/bar")
+		So(size, ShouldEqual, 2)
+	})
+
+	Convey("When pod has a bad pod group size, it doesn't belong to a group", t, func() {
+		_, _, ok := podGroup(getPodGroupPod("foo", "bar", "foo"))
+		So(ok, ShouldBeFalse)
+		_, _, ok = podGroup(getPodGroupPod("foo", "bar", "0"))
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("When pod has no pod group annotation, it doesn't belong to a group", t, func() {
+		_, _, ok := podGroup(getFakePod())
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestReservePodGroupMember(t *testing.T) {
+	c := getDummyCache()
+	pod1 := getPodGroupPod("pod1", "group", "2")
+	pod2 := getPodGroupPod("pod2", "group", "2")
+
+	Convey("When the first member of a group is reserved, the group is incomplete", t, func() {
+		members, err := c.reservePodGroupMemberL("default/group", 2, time.Minute,
+			podGroupMember{pod: pod1, annotation: "card0", nodeName: "node1"})
+		So(err, ShouldBeNil)
+		So(members, ShouldBeNil)
+		So(c.isPodGroupMemberReserved("default/group", pod1), ShouldBeTrue)
+		So(c.isPodGroupMemberReserved("default/group", pod2), ShouldBeFalse)
+		So(c.isPodGroupMemberBound("default/group", pod1), ShouldBeFalse)
+		So(c.nodeStatuses["node1"]["card0"]["gpu.intel.com/i915"], ShouldEqual, 1)
+	})
+
+	Convey("When the last member of a group is reserved, all the members are returned", t, func() {
+		members, err := c.reservePodGroupMemberL("default/group", 2, time.Minute,
+			podGroupMember{pod: pod2, annotation: "card1", nodeName: "node1"})
+		So(err, ShouldBeNil)
+		So(len(members), ShouldEqual, 2)
+		So(c.isPodGroupMemberReserved("default/group", pod1), ShouldBeFalse)
+		So(len(c.podGroups), ShouldEqual, 0)
+		So(c.nodeStatuses["node1"]["card1"]["gpu.intel.com/i915"], ShouldEqual, 1)
+		So(c.isPodGroupMemberBound("default/group", pod1), ShouldBeTrue)
+		So(c.isPodGroupMemberBound("default/group", pod2), ShouldBeTrue)
+	})
+
+	Convey("When an incomplete group times out, its reservations are released", t, func() {
+		c.reset()
+		_, err := c.reservePodGroupMemberL("default/group", 2, 0,
+			podGroupMember{pod: pod1, annotation: "card0", nodeName: "node1"})
+		So(err, ShouldBeNil)
+		c.releaseExpiredPodGroups()
+		So(len(c.podGroups), ShouldEqual, 0)
+		So(c.nodeStatuses["node1"]["card0"]["gpu.intel.com/i915"], ShouldEqual, 0)
+	})
+
+	Convey("When an incomplete group has not timed out, its reservations are kept", t, func() {
+		c.reset()
+		_, err := c.reservePodGroupMemberL("default/group", 2, time.Minute,
+			podGroupMember{pod: pod1, annotation: "card0", nodeName: "node1"})
+		So(err, ShouldBeNil)
+		c.releaseExpiredPodGroups()
+		So(c.isPodGroupMemberReserved("default/group", pod1), ShouldBeTrue)
+	})
+
+	Convey("When a member has another group size than the group, it is rejected", t, func() {
+		c.reset()
+		_, err := c.reservePodGroupMemberL("default/group", 2, time.Minute,
+			podGroupMember{pod: pod1, annotation: "card0", nodeName: "node1"})
+		So(err, ShouldBeNil)
+		_, err = c.reservePodGroupMemberL("default/group", 3, time.Minute,
+			podGroupMember{pod: getPodGroupPod("pod3", "group", "3"), annotation: "card1", nodeName: "node1"})
+		So(errors.Is(err, errPodGroupSizeMismatch), ShouldBeTrue)
+		So(c.nodeStatuses["node1"]["card1"]["gpu.intel.com/i915"], ShouldEqual, 0)
+		So(c.podGroups["default/group"].size, ShouldEqual, 2)
+	})
+
+	Convey("When a reserved member is deleted, its reservation is released once", t, func() {
+		c.reset()
+		_, err := c.reservePodGroupMemberL("default/group", 2, 0,
+			podGroupMember{pod: pod1, annotation: "card0", nodeName: "node1"})
+		So(err, ShouldBeNil)
+		_, err = c.handlePod(podWorkQueueItem{ns: pod1.Namespace, name: pod1.Name, pod: pod1, action: podDeleted})
+		So(err, ShouldBeNil)
+		So(c.isPodGroupMemberReserved("default/group", pod1), ShouldBeFalse)
+		So(len(c.podGroups), ShouldEqual, 0)
+		So(c.nodeStatuses["node1"]["card0"]["gpu.intel.com/i915"], ShouldEqual, 0)
+		c.releaseExpiredPodGroups()
+		So(c.nodeStatuses["node1"]["card0"]["gpu.intel.com/i915"], ShouldEqual, 0)
+	})
+}
+
+func TestBindPodGroup(t *testing.T) {
+	pod1 := getPodGroupPod("pod1", "group", "2")
+	pod2 := getPodGroupPod("pod2", "group", "2")
+
+	gas := getDummyExtender(pod1, pod2)
+
+	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
+	origCacheAPI := iCache
+	iCache = &mockCache
+	args := extender.BindingArgs{PodName: pod1.Name, PodNamespace: pod1.Namespace, Node: nodename}
+
+	Convey("When pod group member is already reserved, it waits for the group", t, func() {
+		mockCache.On("FetchPod", mock.Anything, args.PodNamespace, args.PodName).Return(pod1, nil).Once()
+		mockCache.On("IsPodGroupMemberReserved", mock.Anything, "default/group", pod1).Return(true).Once()
+		result := gas.bindNode(&args)
+		So(result.Error, ShouldEqual, errPodGroupWaiting.Error())
+	})
+
+	Convey("When pod group is incomplete after the reservation, the pod is not bound", t, func() {
+		mockCache.On("FetchPod", mock.Anything, args.PodNamespace, args.PodName).Return(pod1, nil).Once()
+		mockCache.On("IsPodGroupMemberReserved", mock.Anything, "default/group", pod1).Return(false).Once()
+		mockCache.On("IsPodGroupMemberBound", mock.Anything, "default/group", pod1).Return(false).Once()
+		mockCache.On("FetchNode", mock.Anything, args.Node).Return(getMockNode(1, 1), nil).Once()
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(nodeResources{}).Once()
+		mockCache.On("ReservePodGroupMemberL", mock.Anything, "default/group", 2, mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		result := gas.bindNode(&args)
+		So(result.Error, ShouldEqual, errPodGroupWaiting.Error())
+	})
+
+	Convey("When pod group completes with the reservation, all the members are bound", t, func() {
+		mockCache.On("FetchPod", mock.Anything, args.PodNamespace, args.PodName).Return(pod1, nil).Once()
+		mockCache.On("IsPodGroupMemberReserved", mock.Anything, "default/group", pod1).Return(false).Once()
+		mockCache.On("IsPodGroupMemberBound", mock.Anything, "default/group", pod1).Return(false).Once()
+		mockCache.On("FetchNode", mock.Anything, args.Node).Return(getMockNode(1, 1), nil).Once()
+		mockCache.On("GetNodeResourceStatus", mock.Anything, mock.Anything).Return(nodeResources{}).Once()
+		mockCache.On("ReservePodGroupMemberL", mock.Anything, "default/group", 2, mock.Anything, mock.Anything).
+			Return([]podGroupMember{
+				{pod: pod1, annotation: "card0", nodeName: nodename},
+				{pod: pod2, annotation: "card0", nodeName: nodename},
+			}, nil).Once()
+		result := gas.bindNode(&args)
+		So(result.Error, ShouldEqual, "")
+		mockCache.AssertNotCalled(t, "AdjustPodResourcesL",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	Convey("When a member is retried after its group completed, the bind succeeds without a new reservation", t, func() {
+		mockCache.On("FetchPod", mock.Anything, args.PodNamespace, args.PodName).Return(pod1, nil).Once()
+		mockCache.On("IsPodGroupMemberReserved", mock.Anything, "default/group", pod1).Return(false).Once()
+		mockCache.On("IsPodGroupMemberBound", mock.Anything, "default/group", pod1).Return(true).Once()
+		result := gas.bindNode(&args)
+		So(result.Error, ShouldEqual, "")
+		mockCache.AssertNumberOfCalls(t, "ReservePodGroupMemberL", 2)
+		mockCache.AssertNotCalled(t, "AdjustPodResourcesL",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	iCache = origCacheAPI
+}
@@ -0,0 +1,271 @@
+package gpuscheduler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+const (
+	debugReconcilePath = "/debug/reconcile"
+)
+
+// reconcileProblem is an inconsistency found in the gpu annotations of a pod at startup.
+type reconcileProblem struct {
+	Pod     string `json:"pod"`
+	Node    string `json:"node"`
+	Problem string `json:"problem"`
+}
+
+// reconcileL checks the gpu annotations of all the pods in the cache against the nodes and each
+// other, and stores the found problems. This must be called with rwmutex unlocked.
+func (c *Cache) reconcileL() {
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("cannot list pods for reconciliation: %v", err)
+
+		return
+	}
+
+	problems := reconcilePods(pods, c.fetchNode)
+
+	for _, problem := range problems {
+		klog.Warningf("reconciliation: pod %v on node %v: %v", problem.Pod, problem.Node, problem.Problem)
+	}
+
+	klog.V(l2).Infof("reconciled gpu annotations of %v pods, %v problems found", len(pods), len(problems))
+
+	c.rwmutex.Lock()
+	defer c.rwmutex.Unlock()
+
+	c.reconcileProblems = problems
+}
+
+// rebuildL reconciles the gpu annotations of the pods in the cache, and rebuilds the resource usage
+// from the annotations. Pod group reservations are dropped. This must be called with rwmutex unlocked.
+func (c *Cache) rebuildL() error {
+	c.reconcileL()
+
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("pod list error: %w", err)
+	}
+
+	c.rwmutex.Lock()
+	defer c.rwmutex.Unlock()
+
+	c.rebuild(pods)
+
+	return nil
+}
+
+// rebuild replaces the resource usage of the cache with that of the given annotated pods.
+// This must be called with rwmutex locked.
+func (c *Cache) rebuild(pods []*v1.Pod) {
+	c.annotatedPods = map[string]string{}
+	c.nodeStatuses = map[string]nodeResources{}
+	c.nodeTileStatuses = map[string]nodeTiles{}
+	c.nodeCardPods = map[string]cardPods{}
+	c.podGroups = map[string]*podGroupReservation{}
+
+	for _, pod := range pods {
+		annotation, ok := pod.Annotations[cardAnnotationName]
+		if !ok || pod.Spec.NodeName == "" || isCompletedPod(pod) {
+			continue
+		}
+
+		err := c.adjustPodResources(pod, add, annotation, pod.Annotations[tileAnnotationName], pod.Spec.NodeName)
+		if err != nil {
+			klog.Warningf("pod %v resources couldn't be added: %v", namespacedPodName(pod), err)
+		}
+	}
+
+	klog.V(l2).Infof("cache rebuilt from %v pods", len(c.annotatedPods))
+}
+
+// getReconcileProblems returns a copy of the problems found by the startup reconciliation.
+func (c *Cache) getReconcileProblems() []reconcileProblem {
+	c.rwmutex.RLock()
+	defer c.rwmutex.RUnlock()
+
+	return append([]reconcileProblem{}, c.reconcileProblems...)
+}
+
+// DebugReconcile writes the problems found in the gpu annotations of the pods at startup.
+func (m *GASExtender) DebugReconcile(w http.ResponseWriter, r *http.Request) {
+	klog.V(l4).Info("reconcile request received")
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	m.writeResponse(w, iCache.GetReconcileProblems(m.cache))
+}
+
+// reconcilePods tallies the gpu resources and tiles of the annotated pods per node, like the cache
+// does, and returns the problems found on the way: annotations which don't match the containers or
+// the cards of the node, tiles used by several pods, cards of exclusive pods used by other pods and cards
+// used beyond their capacity.
+func reconcilePods(pods []*v1.Pod, fetchNode func(string) (*v1.Node, error)) []reconcileProblem {
+	problems := []reconcileProblem{}
+	tally := &Cache{
+		annotatedPods:    map[string]string{},
+		nodeStatuses:     map[string]nodeResources{},
+		nodeTileStatuses: map[string]nodeTiles{},
+		nodeCardPods:     map[string]cardPods{},
+	}
+	// tileUsers has the pod using each tile, by node, card and tile
+	tileUsers := map[string]string{}
+	// cardUsers and exclusiveUsers have the first pod and the exclusive pod using each card, by node and card
+	cardUsers := map[string]string{}
+	exclusiveUsers := map[string]string{}
+
+	sortedPods := append([]*v1.Pod{}, pods...)
+	sort.Slice(sortedPods, func(i, j int) bool {
+		return namespacedPodName(sortedPods[i]) < namespacedPodName(sortedPods[j])
+	})
+
+	for _, pod := range sortedPods {
+		annotation, ok := pod.Annotations[cardAnnotationName]
+		if !ok || pod.Spec.NodeName == "" || isCompletedPod(pod) {
+			continue
+		}
+
+		report := func(format string, args ...interface{}) {
+			problems = append(problems, reconcileProblem{
+				Pod: namespacedPodName(pod), Node: pod.Spec.NodeName, Problem: fmt.Sprintf(format, args...),
+			})
+		}
+
+		node, err := fetchNode(pod.Spec.NodeName)
+		if err != nil {
+			report("node not found")
+
+			continue
+		}
+
+		gpuNames := getNodeGPUList(node)
+		gpuMap := createGPUMap(gpuNames)
+		podCards := reconcileCards(annotation)
+
+		for _, cardName := range podCards {
+			if !gpuMap[cardName] {
+				report("card %v does not exist in the node", cardName)
+			}
+		}
+
+		tileAnnotation := pod.Annotations[tileAnnotationName]
+
+		if err := tally.adjustPodResources(pod, add, annotation, tileAnnotation, pod.Spec.NodeName); err != nil {
+			report("annotation %q does not match the containers: %v", annotation, err)
+
+			continue
+		}
+
+		for _, tile := range reconcileTiles(tileAnnotation) {
+			tileKey := pod.Spec.NodeName + "/" + tile
+			if user, used := tileUsers[tileKey]; used {
+				report("tile %v is also used by pod %v", tile, user)
+			} else {
+				tileUsers[tileKey] = namespacedPodName(pod)
+			}
+		}
+
+		exclusive := isExclusivePod(pod)
+
+		for _, cardName := range podCards {
+			cardKey := pod.Spec.NodeName + "/" + cardName
+			if user, used := exclusiveUsers[cardKey]; used {
+				report("card %v is used exclusively by pod %v", cardName, user)
+			} else if user, used := cardUsers[cardKey]; used && exclusive {
+				report("exclusive card %v is also used by pod %v", cardName, user)
+			}
+
+			if _, used := cardUsers[cardKey]; !used {
+				cardUsers[cardKey] = namespacedPodName(pod)
+			}
+
+			if exclusive {
+				exclusiveUsers[cardKey] = namespacedPodName(pod)
+			}
+		}
+
+		capacities := getGPUResourceCapacities(node, gpuNames)
+
+		for _, cardName := range podCards {
+			used := tally.nodeStatuses[pod.Spec.NodeName][cardName]
+			capacity := capacities[cardName]
+
+			for _, resName := range sortedResourceNames(used) {
+				// the exclusive use of a card has no capacity, it is checked above
+				if gpuMap[cardName] && resName != gpuExclusiveResource && used[resName] > capacity[resName] {
+					report("card %v %v over capacity: %v used of %v",
+						cardName, resName, used[resName], capacity[resName])
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// reconcileCards returns the distinct cards of a card annotation, in order of appearance.
+func reconcileCards(annotation string) []string {
+	cards := []string{}
+	seen := map[string]bool{}
+
+	for _, containerCards := range allContainerAnnotations(annotation) {
+		for _, cardName := range strings.Split(containerCards, ",") {
+			if cardName != "" && !seen[cardName] {
+				seen[cardName] = true
+				cards = append(cards, cardName)
+			}
+		}
+	}
+
+	return cards
+}
+
+// reconcileTiles returns the tiles of a tile annotation in card:gtN form, e.g. "card0:gt1".
+func reconcileTiles(tileAnnotation string) []string {
+	tiles := []string{}
+
+	for _, containerTiles := range allContainerAnnotations(tileAnnotation) {
+		for _, gpuString := range strings.Split(containerTiles, ",") {
+			gpuParts := strings.Split(gpuString, ":")
+			if len(gpuParts) != expectedGpuSplitCount {
+				continue
+			}
+
+			for _, tileIndex := range getTileIndices(strings.Split(gpuParts[1], "+")) {
+				tiles = append(tiles, fmt.Sprintf("%v:%v%d", gpuParts[0], tileString, tileIndex))
+			}
+		}
+	}
+
+	return tiles
+}
+
+func sortedResourceNames(resources resourceMap) []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func namespacedPodName(pod *v1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
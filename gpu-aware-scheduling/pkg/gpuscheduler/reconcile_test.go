@@ -0,0 +1,122 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getAnnotatedPod(name, nodeName, annotation, tileAnnotation string) *v1.Pod {
+	pod := getFakePod()
+	pod.Spec = *getMockPodSpecWithTile(1)
+	pod.Name = name
+	pod.Namespace = "default"
+	pod.Spec.NodeName = nodeName
+	pod.Annotations[cardAnnotationName] = annotation
+	pod.Annotations[tileAnnotationName] = tileAnnotation
+
+	return pod
+}
+
+func TestReconcilePods(t *testing.T) {
+	node := getMockNode(1, 2, "card0", "card1")
+	node.Labels["gpu.intel.com/cards"] = "card0.card1"
+
+	fetchNode := func(nodeName string) (*v1.Node, error) {
+		if nodeName == "node1" {
+			return node, nil
+		}
+
+		return nil, errMock
+	}
+
+	Convey("When the pod annotations are consistent, no problems are found", t, func() {
+		problems := reconcilePods([]*v1.Pod{
+			getAnnotatedPod("pod1", "node1", "card0", "card0:gt0"),
+			getAnnotatedPod("pod2", "node1", "card1", "card1:gt0"),
+			getAnnotatedPod("pod3", "", "", ""),
+		}, fetchNode)
+		So(problems, ShouldResemble, []reconcileProblem{})
+	})
+
+	Convey("When cards and tiles are double-booked, the later pods are reported", t, func() {
+		problems := reconcilePods([]*v1.Pod{
+			getAnnotatedPod("pod2", "node1", "card0", "card0:gt0"),
+			getAnnotatedPod("pod1", "node1", "card0", "card0:gt0"),
+		}, fetchNode)
+		So(problems, ShouldResemble, []reconcileProblem{
+			{Pod: "default/pod2", Node: "node1", Problem: "tile card0:gt0 is also used by pod default/pod1"},
+			{Pod: "default/pod2", Node: "node1", Problem: "card card0 gpu.intel.com/i915 over capacity: 2 used of 1"},
+			{Pod: "default/pod2", Node: "node1", Problem: "card card0 gpu.intel.com/tiles over capacity: 2 used of 1"},
+		})
+	})
+
+	Convey("When a card of an exclusive pod is used by another pod, the pods are reported", t, func() {
+		exclusivePod := getAnnotatedPod("pod1", "node1", "card0", "card0:gt0")
+		exclusivePod.Annotations[exclusiveAnnotationName] = "true"
+		problems := reconcilePods([]*v1.Pod{
+			exclusivePod,
+			getAnnotatedPod("pod2", "node1", "card0", "card0:gt1"),
+			getAnnotatedPod("pod3", "node1", "card1", "card1:gt0"),
+		}, fetchNode)
+		So(problems, ShouldResemble, []reconcileProblem{
+			{Pod: "default/pod2", Node: "node1", Problem: "card card0 is used exclusively by pod default/pod1"},
+			{Pod: "default/pod2", Node: "node1", Problem: "card card0 gpu.intel.com/i915 over capacity: 2 used of 1"},
+			{Pod: "default/pod2", Node: "node1", Problem: "card card0 gpu.intel.com/tiles over capacity: 2 used of 1"},
+		})
+	})
+
+	Convey("When annotations don't match the node or the containers, the pods are reported", t, func() {
+		problems := reconcilePods([]*v1.Pod{
+			getAnnotatedPod("pod1", "node1", "card2", ""),
+			getAnnotatedPod("pod2", "node1", "card0|card1", ""),
+			getAnnotatedPod("pod3", "node2", "card0", ""),
+		}, fetchNode)
+		So(len(problems), ShouldEqual, 3)
+		So(problems[0].Problem, ShouldEqual, "card card2 does not exist in the node")
+		So(problems[1].Problem, ShouldStartWith, `annotation "card0|card1" does not match the containers`)
+		So(problems[2].Problem, ShouldEqual, "node not found")
+	})
+
+	Convey("When a pod has completed, its annotations are not checked", t, func() {
+		pod := getAnnotatedPod("pod1", "node2", "card0", "")
+		pod.DeletionTimestamp = &metav1.Time{}
+		So(reconcilePods([]*v1.Pod{pod}, fetchNode), ShouldResemble, []reconcileProblem{})
+	})
+}
+
+func TestDebugReconcile(t *testing.T) {
+	gas := getEmptyExtender()
+	mockCache := MockCacheAPI{}
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	Convey("When the reconciliation problems are requested, they are returned", t, func() {
+		problems := []reconcileProblem{{Pod: "default/pod1", Node: "node1", Problem: "node not found"}}
+		mockCache.On("GetReconcileProblems", mock.Anything).Return(problems).Once()
+
+		w := httptest.NewRecorder()
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://foo"+debugReconcilePath, nil)
+		So(err, ShouldBeNil)
+		gas.DebugReconcile(w, request)
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		result := []reconcileProblem{}
+		So(json.NewDecoder(w.Body).Decode(&result), ShouldBeNil)
+		So(result, ShouldResemble, problems)
+	})
+
+	iCache = origCacheAPI
+}
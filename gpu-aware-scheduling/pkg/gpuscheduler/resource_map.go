@@ -72,6 +72,15 @@ func (rm resourceMap) subtractRM(src resourceMap) error {
 	return nil
 }
 
+// maxRM raises the resources of the map to those of the src resourceMap, where src has more.
+func (rm resourceMap) maxRM(src resourceMap) {
+	for key, value := range src {
+		if value > rm[key] {
+			rm[key] = value
+		}
+	}
+}
+
 // add adds a resource to the map.
 // It assumes being used on a sane resource map with positive values.
 func (rm resourceMap) add(key string, value int64) error {
@@ -117,3 +117,14 @@ func TestSubtractRM(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 }
+
+func TestMaxRM(t *testing.T) {
+	key2 := "foo2"
+	key3 := "foo3"
+	rm := resourceMap{key: 2, key2: 5}
+
+	Convey("When I raise an RM to another RM, each resource gets the bigger amount", t, func() {
+		rm.maxRM(resourceMap{key: 4, key2: 3, key3: 1})
+		So(rm, ShouldResemble, resourceMap{key: 4, key2: 5, key3: 1})
+	})
+}
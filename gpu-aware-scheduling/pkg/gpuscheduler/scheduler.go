@@ -16,6 +16,7 @@ import (
 
 	"github.com/intel/platform-aware-scheduling/extender"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -72,22 +73,57 @@ func init() {
 type GASExtender struct {
 	clientset        kubernetes.Interface
 	cache            *Cache
+	scoringPolicies  map[string]scoringPolicy
 	balancedResource string
+	defaultPolicy    string
+	podGroupTimeout  time.Duration
+	metrics          *gasMetrics
 	rwmutex          sync.RWMutex
 	allowlistEnabled bool
 	denylistEnabled  bool
+	leading          bool
 }
 
-// NewGASExtender returns a new GAS Extender.
+// NewGASExtender returns a new GAS Extender. The default policy is used for pods which
+// don't select a scoring policy with an annotation. Incomplete pod groups release their
+// reservations after the pod group timeout.
 func NewGASExtender(clientset kubernetes.Interface, enableAllowlist,
-	enableDenylist bool, balanceResource string) *GASExtender {
-	return &GASExtender{
+	enableDenylist bool, balanceResource, defaultPolicy string, podGroupTimeout time.Duration) *GASExtender {
+	gas := &GASExtender{
 		cache:            iCache.NewCache(clientset),
 		clientset:        clientset,
 		allowlistEnabled: enableAllowlist,
 		denylistEnabled:  enableDenylist,
 		balancedResource: balanceResource,
+		defaultPolicy:    defaultPolicy,
+		podGroupTimeout:  podGroupTimeout,
+		scoringPolicies:  map[string]scoringPolicy{},
+		leading:          true,
 	}
+
+	gas.metrics = newGASMetrics(gas.cache)
+
+	gas.registerScoringPolicy(&binpack{})
+	gas.registerScoringPolicy(&spread{})
+	gas.registerScoringPolicy(&tiles{})
+	gas.registerScoringPolicy(&balanced{})
+	gas.registerScoringPolicy(&preferredCard{})
+
+	if !gas.isScoringPolicyRegistered(defaultPolicy) {
+		klog.ErrorS(errUnknownPolicy, "using the default policy instead",
+			"policy", defaultPolicy, "default", defaultScoringPolicy)
+
+		gas.defaultPolicy = defaultScoringPolicy
+	}
+
+	return gas
+}
+
+// Ready returns true while the extender can serve scheduling requests, i.e. while it has a cache which
+// follows the cluster and it is the leader which binds the pods. The extender stops being ready when its
+// cache stops, e.g. on shutdown, or when it loses the leadership.
+func (m *GASExtender) Ready() bool {
+	return m.cache != nil && iCache.IsReady(m.cache) && m.IsLeading()
 }
 
 func (m *GASExtender) annotatePodBind(annotation, tileAnnotation string, pod *v1.Pod) error {
@@ -194,23 +230,68 @@ func getNodeGPUResourceCapacity(node *v1.Node) resourceMap {
 	return capacity
 }
 
-func getPerGPUResourceCapacity(node *v1.Node, gpuCount int) resourceMap {
-	if gpuCount == 0 {
-		return resourceMap{}
+// getGPUResourceCapacities returns the resource capacity of each of the given gpus. The capacity of a gpu
+// is read from the node label named after the gpu and the resource, e.g. "gpu.intel.com/card0.memory.max".
+// Node capacity which isn't labeled for any gpu is divided evenly to the gpus without the label, so nodes
+// with homogeneous gpus need no labels.
+func getGPUResourceCapacities(node *v1.Node, gpuNames []string) nodeResources {
+	capacities := nodeResources{}
+	for _, gpuName := range gpuNames {
+		capacities[gpuName] = resourceMap{}
 	}
-	// fetch node resource capacity
-	capacity := getNodeGPUResourceCapacity(node)
 
-	// figure out per gpu capacity
-	// (this assumes homogeneous gpus in node, alternative is to start labeling resources per gpu for the nodes)
-	perGPUCapacity := capacity.newCopy()
+	if len(gpuNames) == 0 {
+		return capacities
+	}
 
-	err := perGPUCapacity.divide(gpuCount)
-	if err != nil {
-		return resourceMap{}
+	for resName, nodeCapacity := range getNodeGPUResourceCapacity(node) {
+		unlabeled := []string{}
+
+		for _, gpuName := range gpuNames {
+			if value, ok := gpuLabeledCapacity(node, gpuName, resName); ok {
+				capacities[gpuName][resName] = value
+				nodeCapacity -= value
+			} else {
+				unlabeled = append(unlabeled, gpuName)
+			}
+		}
+
+		if len(unlabeled) == 0 {
+			continue
+		}
+
+		if nodeCapacity < 0 {
+			klog.Warningf("node %v gpu labels exceed the %v capacity of the node", node.Name, resName)
+
+			nodeCapacity = 0
+		}
+
+		for _, gpuName := range unlabeled {
+			capacities[gpuName][resName] = nodeCapacity / int64(len(unlabeled))
+		}
 	}
 
-	return perGPUCapacity
+	return capacities
+}
+
+// gpuLabeledCapacity returns the capacity of the named resource in the given gpu from the node labels,
+// and false if the gpu has no valid label for the resource.
+func gpuLabeledCapacity(node *v1.Node, gpuName, resName string) (int64, bool) {
+	labelName := gpuPrefix + gpuName + "." + strings.TrimPrefix(resName, gpuPrefix)
+
+	labelValue, ok := node.Labels[labelName]
+	if !ok {
+		return 0, false
+	}
+
+	quantity, err := resource.ParseQuantity(labelValue)
+	if err != nil || quantity.Sign() < 0 {
+		klog.Warningf("node %v has a bad gpu capacity label %v=%v", node.Name, labelName, labelValue)
+
+		return 0, false
+	}
+
+	return quantity.Value(), true
 }
 
 func getPerGPUResourceRequest(containerRequest resourceMap) (resourceMap, int64) {
@@ -236,9 +317,21 @@ func getNumI915(containerRequest resourceMap) int64 {
 	return 0
 }
 
-// isGPUUsable returns true, if the GPU is usable.
-func (m *GASExtender) isGPUUsable(gpuName string, node *v1.Node, pod *v1.Pod) bool {
-	return !isGPUDisabled(gpuName, node) && m.isGPUAllowed(gpuName, pod) && !m.isGPUDenied(gpuName, pod)
+// gpuUnusableReason returns the reason why the GPU is not usable, or an empty string if it is usable.
+func (m *GASExtender) gpuUnusableReason(gpuName string, node *v1.Node, pod *v1.Pod) string {
+	if reason := gpuDisableReason(gpuName, node); reason != "" {
+		return reason
+	}
+
+	if !m.isGPUAllowed(gpuName, pod) {
+		return "not in " + allowlistAnnotationName + " annotation"
+	}
+
+	if m.isGPUDenied(gpuName, pod) {
+		return "in " + denylistAnnotationName + " annotation"
+	}
+
+	return ""
 }
 
 // isGPUAllowed returns true, if the given gpuName is allowed. A GPU is considered allowed, if:
@@ -306,19 +399,28 @@ func (m *GASExtender) isGPUDenied(gpuName string, pod *v1.Pod) bool {
 
 // isGPUDisabled returns true if given gpuName should not be used based on node labels.
 func isGPUDisabled(gpuName string, node *v1.Node) bool {
+	return gpuDisableReason(gpuName, node) != ""
+}
+
+// gpuDisableReason returns the reason why the given gpuName is disabled by node labels,
+// or an empty string if it isn't disabled.
+func gpuDisableReason(gpuName string, node *v1.Node) string {
 	// search labels that disable use of this gpu
 	for label, value := range node.Labels {
 		if strippedLabel, ok := labelWithoutTASNS(label); ok {
 			if strings.HasPrefix(strippedLabel, gpuDisableLabelPrefix) {
-				if strings.HasSuffix(label, gpuName) ||
-					(value == pciGroupValue && isGPUInPCIGroup(gpuName, strippedLabel[len(gpuDisableLabelPrefix):], node)) {
-					return true
+				if strings.HasSuffix(label, gpuName) {
+					return "disabled by label " + strippedLabel
+				}
+
+				if value == pciGroupValue && isGPUInPCIGroup(gpuName, strippedLabel[len(gpuDisableLabelPrefix):], node) {
+					return "disabled by PCI group label " + strippedLabel
 				}
 			}
 		}
 	}
 
-	return false
+	return ""
 }
 
 func findNodesPreferredGPU(node *v1.Node) string {
@@ -376,7 +478,7 @@ func getSortedGPUNamesForNode(nodeResourcesUsed nodeResources) []string {
 	return gpuNames
 }
 
-func (m *GASExtender) createTileAnnotation(gpuName string, numCards int64, containerRequest, perGPUCapacity resourceMap,
+func (m *GASExtender) createTileAnnotation(gpuName string, numCards int64, containerRequest, gpuCapacity resourceMap,
 	node *v1.Node, currentlyAllocatingTilesMap map[string][]int, preferredTiles []int) string {
 	requestedTiles := containerRequest[gpuTileResource]
 
@@ -385,14 +487,14 @@ func (m *GASExtender) createTileAnnotation(gpuName string, numCards int64, conta
 		return ""
 	}
 
-	tileCapacityPerGPU := perGPUCapacity[gpuTileResource]
-	if requestedTilesPerGPU < 0 || tileCapacityPerGPU < requestedTilesPerGPU {
+	tileCapacity := gpuCapacity[gpuTileResource]
+	if requestedTilesPerGPU < 0 || tileCapacity < requestedTilesPerGPU {
 		klog.Errorf("bad tile request count: %d", requestedTilesPerGPU)
 
 		return ""
 	}
 
-	freeTiles := m.getFreeTiles(tileCapacityPerGPU, node, gpuName, currentlyAllocatingTilesMap)
+	freeTiles := m.getFreeTiles(tileCapacity, node, gpuName, currentlyAllocatingTilesMap)
 	if len(freeTiles) < int(requestedTilesPerGPU) {
 		klog.Errorf("not enough free tiles")
 
@@ -420,13 +522,13 @@ func (m *GASExtender) createTileAnnotation(gpuName string, numCards int64, conta
 	return annotation
 }
 
-func (m *GASExtender) getFreeTiles(capacityPerGPU int64, node *v1.Node,
+func (m *GASExtender) getFreeTiles(tileCapacity int64, node *v1.Node,
 	gpuName string, currentlyAllocatingTilesMap map[string][]int) []int {
 	nTiles := iCache.GetNodeTileStatus(m.cache, node.Name)
 	freeTilesMap := map[int]bool{}
 
 	// convert capacity to bool search map with indices 0 to capacity-1
-	for i := 0; i < int(capacityPerGPU); i++ {
+	for i := 0; i < int(tileCapacity); i++ {
 		freeTilesMap[i] = true
 	}
 
@@ -450,31 +552,33 @@ func (m *GASExtender) getFreeTiles(capacityPerGPU int64, node *v1.Node,
 	return tiles
 }
 
-func (m *GASExtender) checkGpuAvailability(gpuName string, node *v1.Node, pod *v1.Pod,
-	usedGPUmap map[string]bool, gpuMap map[string]bool) bool {
+// gpuUnavailableReason returns the reason why the gpu can't be used for the container,
+// or an empty string if it is available.
+func (m *GASExtender) gpuUnavailableReason(gpuName string, node *v1.Node, pod *v1.Pod,
+	usedGPUmap map[string]bool, gpuMap map[string]bool) string {
 	if usedGPUmap[gpuName] {
 		klog.V(l4).Infof("gpu %v is already used for this container", gpuName)
 
-		return false
+		return "already used by the container"
 	}
 
 	if !gpuMap[gpuName] {
 		klog.Warningf("node %v gpu %v has vanished", node.Name, gpuName)
 
-		return false
+		return "vanished"
 	}
 
 	// skip GPUs which are not usable and continue to next if need be
-	if !m.isGPUUsable(gpuName, node, pod) {
+	if reason := m.gpuUnusableReason(gpuName, node, pod); reason != "" {
 		klog.V(l4).Infof("node %v gpu %v is not usable, skipping it", node.Name, gpuName)
 
-		return false
+		return reason
 	}
 
-	return true
+	return ""
 }
 
-func (m *GASExtender) getCardsForContainerGPURequest(containerRequest, perGPUCapacity resourceMap,
+func (m *GASExtender) getCardsForContainerGPURequest(containerRequest resourceMap, gpuCapacities nodeResources,
 	node *v1.Node, pod *v1.Pod,
 	nodeResourcesUsed nodeResources,
 	gpuMap map[string]bool) (cards []string, preferred bool, err error) {
@@ -484,32 +588,72 @@ func (m *GASExtender) getCardsForContainerGPURequest(containerRequest, perGPUCap
 		return cards, preferred, nil
 	}
 
+	selector, err := podGPUSelector(pod)
+	if err != nil {
+		klog.Warningf("pod %v has a bad %v annotation: %v", pod.Name, gpuSelectorAnnotationName, err)
+
+		return nil, false, &fitFailure{reason: "bad " + gpuSelectorAnnotationName + " annotation: " + err.Error()}
+	}
+
+	affinity, err := podCardAffinity(pod)
+	if err != nil {
+		klog.Warningf("pod %v has a bad card affinity annotation: %v", pod.Name, err)
+
+		return nil, false, &fitFailure{reason: err.Error()}
+	}
+
+	// the pods using each card, for the card affinity of the pod and the other pods
+	nodeCardPods := iCache.GetNodeCardPods(m.cache, node.Name)
+
 	usedGPUmap := map[string]bool{}
+	exclusive := isExclusivePod(pod)
+	policy, policyArg := m.podScoringPolicy(pod)
 
 	// figure out container resources per gpu
 	perGPUResourceRequest, numI915 := getPerGPUResourceRequest(containerRequest)
 
 	for gpuNum := int64(0); gpuNum < numI915; gpuNum++ {
 		fitted := false
-		preferredCardAtFront := false
+		gpuReasons := map[string]string{}
 		gpuNames := getSortedGPUNamesForNode(nodeResourcesUsed)
+		preferredCardAtFront := policy.arrangeGPUs(gpuNames, nodeResourcesUsed, gpuCapacities, node, policyArg)
 
-		if m.balancedResource != "" {
-			arrangeGPUNamesPerResourceAvailability(nodeResourcesUsed, gpuNames, m.balancedResource)
-		} else if preferredCard := findNodesPreferredGPU(node); preferredCard != "" {
-			movePreferredCardToFront(gpuNames, preferredCard)
-			preferredCardAtFront = true
+		if affinity.moveAffineGPUsToFront(gpuNames, pod, nodeCardPods) {
+			preferredCardAtFront = false
 		}
 
 		for gpuIndex, gpuName := range gpuNames {
 			usedResMap := nodeResourcesUsed[gpuName]
 			klog.V(l4).Info("Checking gpu ", gpuName)
 
-			if !m.checkGpuAvailability(gpuName, node, pod, usedGPUmap, gpuMap) {
+			if reason := m.gpuUnavailableReason(gpuName, node, pod, usedGPUmap, gpuMap); reason != "" {
+				gpuReasons[gpuName] = reason
+
+				continue
+			}
+
+			if reason := exclusiveGPUReason(usedResMap, exclusive); reason != "" {
+				klog.V(l4).Infof("node %v gpu %v: %v, skipping it", node.Name, gpuName, reason)
+				gpuReasons[gpuName] = reason
+
+				continue
+			}
+
+			if reason := affinity.antiAffinityReason(pod, nodeCardPods[gpuName]); reason != "" {
+				klog.V(l4).Infof("node %v gpu %v: %v, skipping it", node.Name, gpuName, reason)
+				gpuReasons[gpuName] = reason
+
+				continue
+			}
+
+			if !selector.matches(gpuAttributes(node, gpuName, gpuCapacities[gpuName])) {
+				klog.V(l4).Infof("node %v gpu %v doesn't match the gpu selector, skipping it", node.Name, gpuName)
+				gpuReasons[gpuName] = "not selected by " + gpuSelectorAnnotationName + " annotation"
+
 				continue
 			}
 
-			if checkResourceCapacity(perGPUResourceRequest, perGPUCapacity, usedResMap) {
+			if checkResourceCapacity(perGPUResourceRequest, gpuCapacities[gpuName], usedResMap) {
 				err := usedResMap.addRM(perGPUResourceRequest)
 				if err == nil {
 					fitted = true
@@ -520,16 +664,20 @@ func (m *GASExtender) getCardsForContainerGPURequest(containerRequest, perGPUCap
 
 					cards = append(cards, gpuName)
 					usedGPUmap[gpuName] = true
+				} else {
+					gpuReasons[gpuName] = err.Error()
 				}
 
 				break
 			}
+
+			gpuReasons[gpuName] = resourceShortage(perGPUResourceRequest, gpuCapacities[gpuName], usedResMap)
 		}
 
 		if !fitted {
 			klog.V(l4).Infof("pod %v will not fit node %v", pod.Name, node.Name)
 
-			return nil, false, errWontFit
+			return nil, false, &fitFailure{gpus: gpuReasons}
 		}
 	}
 
@@ -578,13 +726,34 @@ func (m *GASExtender) getNodeForName(name string) (*v1.Node, error) {
 // checkForSpaceAndRetrieveCards checks if pod fits into a node and returns the cards (gpus)
 // that are assigned to each container. If pod doesn't fit or any other error triggers, error is returned.
 func (m *GASExtender) checkForSpaceAndRetrieveCards(pod *v1.Pod, node *v1.Node) ([][]string, bool, error) {
-	preferred := false
-	containerCards := [][]string{}
+	fit, err := m.fitPodToNode(pod, node)
+
+	return fit.containerCards, fit.preferred, err
+}
+
+// nodeFit is the outcome of fitting the GPU requests of a pod into a node.
+type nodeFit struct {
+	// containerCards has the cards selected for each container
+	containerCards [][]string
+	// initContainerCards has the cards selected for each init container, or nil if no init container uses gpus
+	initContainerCards [][]string
+	// preferred is true if the node preferred gpu got selected
+	preferred bool
+	// gpuCapacities has the resource capacity of each gpu in the node
+	gpuCapacities nodeResources
+	// resourcesUsed has the per gpu used resources, including the fitted pod and unavailable resources
+	resourcesUsed nodeResources
+}
+
+// fitPodToNode does the work for checkForSpaceAndRetrieveCards. On top of the selected cards, it
+// returns the per gpu resource usage of the node as it would be with the pod in place.
+func (m *GASExtender) fitPodToNode(pod *v1.Pod, node *v1.Node) (nodeFit, error) {
+	fit := nodeFit{containerCards: [][]string{}}
 
 	if node == nil {
 		klog.Warningf("checkForSpaceAndRetrieveCards called with nil node")
 
-		return containerCards, preferred, errWontFit
+		return fit, &fitFailure{reason: "node is missing"}
 	}
 
 	gpus := getNodeGPUList(node)
@@ -594,16 +763,16 @@ func (m *GASExtender) checkForSpaceAndRetrieveCards(pod *v1.Pod, node *v1.Node)
 	if gpuCount == 0 {
 		klog.Warningf("Node %s GPUs have vanished", node.Name)
 
-		return containerCards, preferred, errWontFit
+		return fit, &fitFailure{reason: "node has no GPUs"}
 	}
 
-	perGPUCapacity := getPerGPUResourceCapacity(node, gpuCount)
+	fit.gpuCapacities = getGPUResourceCapacities(node, gpus)
 	nodeResourcesUsed, err := m.readNodeResources(node.Name)
 
 	if err != nil {
 		klog.Warningf("Node %s resources couldn't be read or node vanished", node.Name)
 
-		return containerCards, preferred, err
+		return fit, err
 	}
 
 	gpuMap := createGPUMap(gpus)
@@ -611,8 +780,7 @@ func (m *GASExtender) checkForSpaceAndRetrieveCards(pod *v1.Pod, node *v1.Node)
 	addEmptyResourceMaps(gpus, nodeResourcesUsed)
 
 	// create map for unavailable resources
-	tilesPerGpu := perGPUCapacity[gpuTileResource]
-	unavailableResources := m.createUnavailableNodeResources(node, tilesPerGpu)
+	unavailableResources := m.createUnavailableNodeResources(node, fit.gpuCapacities)
 
 	klog.V(l4).Info("Unavailable resources: ", unavailableResources)
 
@@ -621,45 +789,172 @@ func (m *GASExtender) checkForSpaceAndRetrieveCards(pod *v1.Pod, node *v1.Node)
 	// e.g. too high temperature detected on a particular resource
 	addUnavailableToUsedResourced(nodeResourcesUsed, unavailableResources)
 
+	// the gpus used by exclusive pods are fully consumed
+	consumeExclusiveGPUs(nodeResourcesUsed, fit.gpuCapacities)
+
 	klog.V(l4).Info("Used resources: ", nodeResourcesUsed)
 
+	// the init containers which aren't sidecars run before the containers, so they only need
+	// the resources which are in use before the pod, and those of the sidecars started before them
+	baseResourcesUsed := nodeResourcesUsed.newCopy()
+	initRequests := initContainerRequests(pod)
+	sidecars := sidecarContainers(pod)
+
+	if hasInitContainerGPURequests(pod) {
+		fit.initContainerCards = make([][]string, len(initRequests))
+
+		for i, initRequest := range initRequests {
+			if sidecars[i] {
+				if err := m.fitContainer(&fit, initRequest, node, pod, nodeResourcesUsed, gpuMap, i, true); err != nil {
+					return fit, err
+				}
+			}
+		}
+	}
+
 	// select GPUs. Trivial implementation selects first suitable GPUs
 	containerRequests := containerRequests(pod)
 
 	for i, containerRequest := range containerRequests {
-		cards, pref, err := m.getCardsForContainerGPURequest(containerRequest, perGPUCapacity,
-			node, pod, nodeResourcesUsed, gpuMap)
-		if err != nil {
-			klog.V(l4).Info("container %v out of %v did not fit", i+1, len(containerRequests))
+		if err := m.fitContainer(&fit, containerRequest, node, pod, nodeResourcesUsed, gpuMap, i, false); err != nil {
+			return fit, err
+		}
+	}
+
+	fit.resourcesUsed = nodeResourcesUsed
+
+	if fit.initContainerCards != nil {
+		if err := m.fitInitContainers(&fit, node, pod, baseResourcesUsed, gpuMap); err != nil {
+			return fit, err
+		}
+	}
+
+	if isExclusivePod(pod) {
+		for _, cards := range [][][]string{fit.initContainerCards, fit.containerCards} {
+			for _, card := range podCards(cards) {
+				fit.resourcesUsed[card][gpuExclusiveResource] = 1
+			}
+		}
+
+		consumeExclusiveGPUs(fit.resourcesUsed, fit.gpuCapacities)
+	}
+
+	return fit, nil
+}
+
+// fitInitContainers selects the cards for the init containers of the pod, which aren't sidecars, and sets the
+// used resources of the fit to the most the pod uses at any stage. The sidecars are expected to have their
+// cards already, and the base resources to be in use before the pod.
+func (m *GASExtender) fitInitContainers(fit *nodeFit, node *v1.Node, pod *v1.Pod,
+	baseResourcesUsed nodeResources, gpuMap map[string]bool) error {
+	initRequests := initContainerRequests(pod)
+	sidecars := sidecarContainers(pod)
+
+	for i, initRequest := range initRequests {
+		if sidecars[i] {
+			continue
+		}
+
+		initResourcesUsed := baseResourcesUsed.newCopy()
+
+		for j := 0; j < i; j++ {
+			if !sidecars[j] {
+				continue
+			}
+
+			sidecarCards := strings.Join(fit.initContainerCards[j], cardDelimiter)
+			if err := addContainerUsage(initResourcesUsed, initRequests[j], sidecarCards); err != nil {
+				return err
+			}
+		}
 
-			return containerCards, preferred, err
+		if err := m.fitContainer(fit, initRequest, node, pod, initResourcesUsed, gpuMap, i, true); err != nil {
+			return err
 		}
+	}
+
+	// the pod uses the most of each resource it needs at any stage, the init containers included
+	usage, err := podCardUsage(pod, cardAnnotation(fit.initContainerCards, fit.containerCards))
+	if err != nil {
+		return err
+	}
 
-		containerCards = append(containerCards, cards)
+	fit.resourcesUsed = baseResourcesUsed
 
-		if pref {
-			preferred = true
+	for cardName, resources := range usage {
+		if err := fit.resourcesUsed[cardName].addRM(resources); err != nil {
+			return err
 		}
 	}
 
-	return containerCards, preferred, nil
+	return nil
 }
 
-// convertNodeCardsToAnnotations converts given container cards into card and tile
+// fitContainer selects the cards for a container, or an init container, of the pod with the given used
+// resources, and stores them in the fit. The container is the index of the container in the pod spec.
+func (m *GASExtender) fitContainer(fit *nodeFit, request resourceMap, node *v1.Node, pod *v1.Pod,
+	nodeResourcesUsed nodeResources, gpuMap map[string]bool, container int, initContainer bool) error {
+	cards, pref, err := m.getCardsForContainerGPURequest(request, fit.gpuCapacities, node, pod, nodeResourcesUsed, gpuMap)
+	if err != nil {
+		klog.V(l4).Infof("container %v (init container %v) did not fit", container+1, initContainer)
+
+		var failure *fitFailure
+		if errors.As(err, &failure) {
+			failure.container = container + 1
+			failure.initContainer = initContainer
+		}
+
+		return err
+	}
+
+	if initContainer {
+		fit.initContainerCards[container] = cards
+	} else {
+		fit.containerCards = append(fit.containerCards, cards)
+	}
+
+	if pref {
+		fit.preferred = true
+	}
+
+	return nil
+}
+
+// cardAnnotation returns the card annotation for the cards of the init containers and the containers.
+func cardAnnotation(initContainerCards, containerCards [][]string) string {
+	var initParts []string
+
+	if initContainerCards != nil {
+		initParts = make([]string, len(initContainerCards))
+		for i, cards := range initContainerCards {
+			initParts[i] = strings.Join(cards, cardDelimiter)
+		}
+	}
+
+	parts := make([]string, len(containerCards))
+	for i, cards := range containerCards {
+		parts[i] = strings.Join(cards, cardDelimiter)
+	}
+
+	return joinContainerAnnotation(initParts, parts)
+}
+
+// convertNodeCardsToAnnotations converts given container and init container cards into card and tile
 // annotation strings.
 func (m *GASExtender) convertNodeCardsToAnnotations(pod *v1.Pod,
-	node *v1.Node, containerCards [][]string) (annotation, tileAnnotation string) {
-	gpuCount := len(getNodeGPUList(node))
-	klog.V(l4).Info("Node gpu count:", gpuCount)
+	node *v1.Node, containerCards, initContainerCards [][]string) (annotation, tileAnnotation string) {
+	gpus := getNodeGPUList(node)
+	klog.V(l4).Info("Node gpu count:", len(gpus))
 
-	perGPUCapacity := getPerGPUResourceCapacity(node, gpuCount)
+	gpuCapacities := getGPUResourceCapacities(node, gpus)
 
 	containerRequests := containerRequests(pod)
-	containerDelimeter := ""
+	initRequests := initContainerRequests(pod)
 
-	if len(containerRequests) != len(containerCards) {
-		klog.Errorf("sizes for containers and container cards do not match: %v vs %v",
-			len(containerRequests), len(containerCards))
+	if len(containerRequests) != len(containerCards) ||
+		(initContainerCards != nil && len(initRequests) != len(initContainerCards)) {
+		klog.Errorf("sizes for containers and container cards do not match: %v vs %v, init %v vs %v",
+			len(containerRequests), len(containerCards), len(initRequests), len(initContainerCards))
 
 		return "", ""
 	}
@@ -668,38 +963,74 @@ func (m *GASExtender) convertNodeCardsToAnnotations(pod *v1.Pod,
 	// get used even though they might be currently free for use
 	unusableTilesMap, prefTileMap := createDisabledAndPreferredTileMapping(node.Labels)
 
-	tilesPerGpu := perGPUCapacity[gpuTileResource]
 	// it is possible to have an invalid rule which would disable a non existing
 	// tile which would reduce the available resources even though it's not needed
-	unusableTilesMap = sanitizeTiles(unusableTilesMap, int(tilesPerGpu))
+	unusableTilesMap = sanitizeTiles(unusableTilesMap, gpuCapacities)
 
-	for i, containerRequest := range containerRequests {
-		cards := containerCards[i]
+	// the tiles are given in the order the containers start. The running sidecars keep their
+	// tiles, and the tiles of the finished init containers are given to the later containers first.
+	runningTilesMap := map[string][]int{}
+	podTilesMap := map[string][]int{}
 
-		usesTiles := containerHasTiles(containerRequest)
+	var initTileParts []string
 
-		annotation += containerDelimeter
-		tileAnnotation += containerDelimeter
-		cardDelimeter := ""
+	if initContainerCards != nil {
+		initTileParts = make([]string, len(initContainerCards))
+		sidecars := sidecarContainers(pod)
 
-		for _, card := range cards {
-			annotation += cardDelimeter + card
+		for i, cards := range initContainerCards {
+			allocatingTilesMap := copyTileMapping(unusableTilesMap)
+			combineMappings(runningTilesMap, allocatingTilesMap)
 
-			prefTiles := prefTileMap[card]
-			if usesTiles {
-				tiles := m.createTileAnnotation(card, int64(len(cards)),
-					containerRequest, perGPUCapacity, node, unusableTilesMap, prefTiles)
+			tiles, selectedTiles := m.createContainerTileAnnotation(initRequests[i], cards, gpuCapacities,
+				node, allocatingTilesMap, podTilesMap, prefTileMap)
+			initTileParts[i] = tiles
 
-				tileAnnotation += cardDelimeter + tiles
+			if sidecars[i] {
+				combineMappings(selectedTiles, runningTilesMap)
 			}
-
-			cardDelimeter = ","
 		}
+	}
+
+	allocatingTilesMap := copyTileMapping(unusableTilesMap)
+	combineMappings(runningTilesMap, allocatingTilesMap)
+
+	tileParts := make([]string, len(containerCards))
 
-		containerDelimeter = "|"
+	for i, cards := range containerCards {
+		tileParts[i], _ = m.createContainerTileAnnotation(containerRequests[i], cards, gpuCapacities,
+			node, allocatingTilesMap, podTilesMap, prefTileMap)
 	}
 
-	return annotation, tileAnnotation
+	return cardAnnotation(initContainerCards, containerCards), joinContainerAnnotation(initTileParts, tileParts)
+}
+
+// createContainerTileAnnotation creates the tile annotation of a container for the given cards. The selected
+// tiles are added to the allocating tiles and to the tiles of the pod, and returned. Tiles which the pod already
+// has are selected first, followed by the preferred tiles of the node.
+func (m *GASExtender) createContainerTileAnnotation(containerRequest resourceMap, cards []string,
+	gpuCapacities nodeResources, node *v1.Node,
+	allocatingTilesMap, podTilesMap, prefTileMap map[string][]int) (string, map[string][]int) {
+	selectedTiles := map[string][]int{}
+
+	if !containerHasTiles(containerRequest) {
+		return "", selectedTiles
+	}
+
+	tileParts := make([]string, len(cards))
+
+	for i, card := range cards {
+		allocated := len(allocatingTilesMap[card])
+		prefTiles := appendNewTiles(append([]int{}, podTilesMap[card]...), prefTileMap[card])
+
+		tileParts[i] = m.createTileAnnotation(card, int64(len(cards)),
+			containerRequest, gpuCapacities[card], node, allocatingTilesMap, prefTiles)
+
+		selectedTiles[card] = append(selectedTiles[card], allocatingTilesMap[card][allocated:]...)
+		podTilesMap[card] = appendNewTiles(podTilesMap[card], selectedTiles[card])
+	}
+
+	return strings.Join(tileParts, cardDelimiter), selectedTiles
 }
 
 func containerHasTiles(resources resourceMap) bool {
@@ -708,14 +1039,14 @@ func containerHasTiles(resources resourceMap) bool {
 	return (found && amount > 0)
 }
 
-func (m *GASExtender) createUnavailableNodeResources(node *v1.Node, tilesPerGpu int64) nodeResources {
+func (m *GASExtender) createUnavailableNodeResources(node *v1.Node, gpuCapacities nodeResources) nodeResources {
 	nodeRes := nodeResources{}
 
 	// for now, only "supported" unavailable resource is tiles
 	disabledTilesMap := createDisabledTileMapping(node.Labels)
 	// it is possible to have an invalid rule which would disable a non existing
 	// tile which would reduce the available resources even though it's not needed
-	disabledTilesMap = sanitizeTiles(disabledTilesMap, int(tilesPerGpu))
+	disabledTilesMap = sanitizeTiles(disabledTilesMap, gpuCapacities)
 
 	usedTilesStats := m.cache.nodeTileStatuses[node.Name]
 
@@ -803,6 +1134,11 @@ func (m *GASExtender) retrievePod(podName, podNamespace string, uid types.UID) (
 func (m *GASExtender) bindNode(args *extender.BindingArgs) *extender.BindingResult {
 	result := extender.BindingResult{}
 
+	var err error
+
+	defer m.metrics.observeDuration(bindVerb, time.Now())
+	defer func() { m.metrics.countBind(err) }()
+
 	pod, err := m.retrievePod(args.PodName, args.PodNamespace, args.PodUID)
 	if err != nil {
 		result.Error = err.Error()
@@ -810,10 +1146,35 @@ func (m *GASExtender) bindNode(args *extender.BindingArgs) *extender.BindingResu
 		return &result
 	}
 
+	lockStart := time.Now()
 	m.rwmutex.Lock()
+	m.metrics.observeLockWait(bindVerb, lockStart)
 	klog.V(l5).Infof("bind %v:%v to node %v locked", args.PodNamespace, args.PodName, args.Node)
 	defer m.rwmutex.Unlock()
 
+	if !m.leading {
+		err = errNotLeader
+		result.Error = err.Error()
+
+		return &result
+	}
+
+	group, groupSize, inGroup := podGroup(pod)
+	if inGroup && iCache.IsPodGroupMemberReserved(m.cache, group, pod) {
+		klog.V(l3).Infof("pod %v:%v is already reserved in pod group %v", args.PodNamespace, args.PodName, group)
+		err = errPodGroupWaiting
+		result.Error = err.Error()
+
+		return &result
+	}
+
+	// a retry of a member which GAS bound when the group completed must not take the resources again
+	if inGroup && iCache.IsPodGroupMemberBound(m.cache, group, pod) {
+		klog.V(l3).Infof("pod %v:%v of pod group %v is already bound", args.PodNamespace, args.PodName, group)
+
+		return &result
+	}
+
 	resourcesAdjusted := false
 	annotation, tileAnnotation := "", ""
 
@@ -824,9 +1185,9 @@ func (m *GASExtender) bindNode(args *extender.BindingArgs) *extender.BindingResu
 
 			if resourcesAdjusted {
 				// Restore resources to cache. Removing resources should not fail if adding was ok.
-				err = iCache.AdjustPodResourcesL(m.cache, pod, remove, annotation, tileAnnotation, args.Node)
-				if err != nil {
-					klog.Warning("adjust pod resources failed", err.Error())
+				adjErr := iCache.AdjustPodResourcesL(m.cache, pod, remove, annotation, tileAnnotation, args.Node)
+				if adjErr != nil {
+					klog.Warning("adjust pod resources failed", adjErr.Error())
 				}
 			}
 		}
@@ -838,12 +1199,12 @@ func (m *GASExtender) bindNode(args *extender.BindingArgs) *extender.BindingResu
 		return &result
 	}
 
-	cards, _, err := m.checkForSpaceAndRetrieveCards(pod, node)
+	fit, err := m.fitPodToNode(pod, node)
 	if err != nil {
 		return &result
 	}
 
-	annotation, tileAnnotation = m.convertNodeCardsToAnnotations(pod, node, cards)
+	annotation, tileAnnotation = m.convertNodeCardsToAnnotations(pod, node, fit.containerCards, fit.initContainerCards)
 	if annotation == "" {
 		return &result
 	}
@@ -851,6 +1212,14 @@ func (m *GASExtender) bindNode(args *extender.BindingArgs) *extender.BindingResu
 	klog.V(l3).Infof("bind %v:%v to node %v annotation %v tileAnnotation %v",
 		args.PodNamespace, args.PodName, args.Node, annotation, tileAnnotation)
 
+	if inGroup {
+		err = m.reservePodGroupMember(group, groupSize, podGroupMember{
+			pod: pod, annotation: annotation, tileAnnotation: tileAnnotation, nodeName: args.Node,
+		})
+
+		return &result
+	}
+
 	err = iCache.AdjustPodResourcesL(m.cache, pod, add, annotation, tileAnnotation, args.Node)
 	if err != nil {
 		return &result
@@ -881,8 +1250,13 @@ func (m *GASExtender) filterNodes(args *extender.Args) *extender.FilterResult {
 	var preferredNodeNames []string
 
 	failedNodes := extender.FailedNodesMap{}
+	// failedReasons has the reasons without the details, to keep the number of metric labels bounded
+	failedReasons := map[string]string{}
 	result := extender.FilterResult{}
 
+	defer m.metrics.observeDuration(filterVerb, time.Now())
+	defer func() { m.metrics.countFilter(result.Error != "", failedReasons) }()
+
 	if args.NodeNames == nil || len(*args.NodeNames) == 0 {
 		result.Error = "No nodes to compare. " +
 			"This should not happen, perhaps the extender is misconfigured with NodeCacheCapable == false."
@@ -891,14 +1265,17 @@ func (m *GASExtender) filterNodes(args *extender.Args) *extender.FilterResult {
 		return &result
 	}
 
+	lockStart := time.Now()
 	m.rwmutex.Lock()
+	m.metrics.observeLockWait(filterVerb, lockStart)
 	klog.V(l5).Infof("filter %v:%v from %v locked", args.Pod.Namespace, args.Pod.Name, *args.NodeNames)
 	defer m.rwmutex.Unlock()
 
 	for _, nodeName := range *args.NodeNames {
 		node, err := m.getNodeForName(nodeName)
 		if err != nil {
-			failedNodes[nodeName] = "Couldn't retrieve node's information"
+			failedNodes[nodeName] = nodeUnreadableReason
+			failedReasons[nodeName] = nodeUnreadableReason
 
 			continue
 		}
@@ -910,7 +1287,8 @@ func (m *GASExtender) filterNodes(args *extender.Args) *extender.FilterResult {
 				nodeNames = append(nodeNames, nodeName)
 			}
 		} else {
-			failedNodes[nodeName] = "Not enough GPU-resources for deployment"
+			failedNodes[nodeName] = fitFailureReason(err)
+			failedReasons[nodeName] = notEnoughGPUReason
 		}
 	}
 
@@ -964,10 +1342,25 @@ func (m *GASExtender) writeResponse(w http.ResponseWriter, result interface{}) {
 	}
 }
 
-// Prioritize manages all prioritize requests from the scheduler extender.
-// Not implemented yet by GAS, hence response with StatusNotFound.
+// Prioritize manages all prioritize requests from the scheduler extender. First it decodes the request,
+// then it scores the nodes with the configured scoring policy and writes a response to the scheduler.
 func (m *GASExtender) Prioritize(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotFound)
+	klog.V(l4).Info("prioritize request received")
+
+	extenderArgs := extender.Args{}
+	err := m.decodeRequest(&extenderArgs, r)
+
+	if err != nil {
+		klog.Errorf("cannot decode request %v", err)
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	prioritizedNodes := m.prioritizeNodes(&extenderArgs)
+
+	m.writeResponse(w, prioritizedNodes)
+	klog.V(l4).Info("prioritize function done, responded")
 }
 
 // Filter manages all filter requests from the scheduler. First it decodes the request,
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -33,7 +34,7 @@ const (
 func getDummyExtender(objects ...runtime.Object) *GASExtender {
 	clientset := fake.NewSimpleClientset(objects...)
 
-	return NewGASExtender(clientset, true, true, "")
+	return NewGASExtender(clientset, true, true, "", preferredCardPolicy, 0)
 }
 
 //nolint: gochecknoglobals // only test resource
@@ -148,12 +149,45 @@ func getMockNode(sharedDevCount, tileCountPerCard int, cardNames ...string) *v1.
 func TestNewGASExtender(t *testing.T) {
 	Convey("When I create a new gas extender", t, func() {
 		Convey("and InClusterConfig returns an error", func() {
-			gas := NewGASExtender(nil, false, false, "")
+			gas := NewGASExtender(nil, false, false, "", preferredCardPolicy, 0)
 			So(gas.clientset, ShouldBeNil)
 		})
 	})
 }
 
+func TestReady(t *testing.T) {
+	Convey("When the extender has a synced cache, it is ready", t, func() {
+		So(getEmptyExtender().Ready(), ShouldBeTrue)
+	})
+
+	Convey("When the extender has no cache, it is not ready", t, func() {
+		So((&GASExtender{}).Ready(), ShouldBeFalse)
+	})
+
+	Convey("When the cache informers haven't synced, the extender is not ready", t, func() {
+		c := createMockCache()
+		c.informersSynced = []cache.InformerSynced{func() bool { return true }, func() bool { return false }}
+		So((&GASExtender{cache: c, leading: true}).Ready(), ShouldBeFalse)
+
+		c.informersSynced[1] = func() bool { return true }
+		So((&GASExtender{cache: c, leading: true}).Ready(), ShouldBeTrue)
+	})
+
+	Convey("When the cache workers have been stopped, the extender is not ready", t, func() {
+		c := createMockCache()
+		c.podWorkQueue.ShutDown()
+		So((&GASExtender{cache: c, leading: true}).Ready(), ShouldBeFalse)
+	})
+
+	Convey("When the extender follows the leader replica, it is not ready", t, func() {
+		gas := &GASExtender{cache: createMockCache()}
+		So(gas.Ready(), ShouldBeFalse)
+
+		gas.leading = true
+		So(gas.Ready(), ShouldBeTrue)
+	})
+}
+
 func TestSchedulingLogicBadParams(t *testing.T) {
 	gas := getEmptyExtender()
 	mockCache := MockCacheAPI{}
@@ -263,6 +297,7 @@ func TestBindNode(t *testing.T) {
 	gas := getDummyExtender(pod)
 
 	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
 	origCacheAPI := iCache
 	iCache = &mockCache
 	args := extender.BindingArgs{}
@@ -329,6 +364,7 @@ func TestAllowlist(t *testing.T) {
 
 	gas := getDummyExtender(pod)
 	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
 	origCacheAPI := iCache
 	iCache = &mockCache
 	args := extender.BindingArgs{}
@@ -365,6 +401,7 @@ func TestDenylist(t *testing.T) {
 
 	gas := getDummyExtender(pod)
 	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
 	origCacheAPI := iCache
 	iCache = &mockCache
 	args := extender.BindingArgs{}
@@ -407,6 +444,7 @@ func TestGPUDisabling(t *testing.T) {
 
 	gas := getDummyExtender(pod)
 	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
 	origCacheAPI := iCache
 	iCache = &mockCache
 	args := extender.BindingArgs{}
@@ -473,13 +511,14 @@ func TestPreferredGPU(t *testing.T) {
 	pod := getFakePod()
 
 	containerRequest := resourceMap{"gpu.intel.com/i915": 1}
-	perGPUCapacity := resourceMap{"gpu.intel.com/i915": 1}
+	gpuCapacities := nodeResources{"card0": resourceMap{"gpu.intel.com/i915": 1},
+		"card1": resourceMap{"gpu.intel.com/i915": 1}, "card2": resourceMap{"gpu.intel.com/i915": 1}}
 
 	nodeResourcesUsed := nodeResources{"card0": resourceMap{}, "card1": resourceMap{}, "card2": resourceMap{}}
 	gpuMap := map[string]bool{"card0": true, "card1": true, "card2": true}
 
 	Convey("When a gpu is not preferred, alphabetically first gpu should be selected", t, func() {
-		cards, preferred, err := gas.getCardsForContainerGPURequest(containerRequest, perGPUCapacity,
+		cards, preferred, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
 			node, pod,
 			nodeResourcesUsed,
 			gpuMap)
@@ -492,7 +531,7 @@ func TestPreferredGPU(t *testing.T) {
 
 	Convey("When a gpu is preferred, it should be selected", t, func() {
 		node.Labels["telemetry.aware.scheduling.policy/gas-prefer-gpu"] = "card2"
-		cards, preferred, err := gas.getCardsForContainerGPURequest(containerRequest, perGPUCapacity,
+		cards, preferred, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
 			node, pod,
 			nodeResourcesUsed,
 			gpuMap)
@@ -736,14 +775,15 @@ func TestResourceBalancedCardsForContainerGPURequest(t *testing.T) {
 	pod := getFakePod()
 
 	containerRequest := resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/foo": 1}
-	perGPUCapacity := resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/foo": 4}
+	capacity := resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/foo": 4}
+	gpuCapacities := nodeResources{"card0": capacity, "card1": capacity, "card2": capacity}
 
 	nodeResourcesUsed := nodeResources{"card0": resourceMap{"gpu.intel.com/foo": 1},
 		"card1": resourceMap{"gpu.intel.com/foo": 2}, "card2": resourceMap{}}
 	gpuMap := map[string]bool{"card0": true, "card1": true, "card2": true}
 
 	Convey("When GPUs are resource balanced, the least consumed GPU should be used", t, func() {
-		cards, preferred, err := gas.getCardsForContainerGPURequest(containerRequest, perGPUCapacity,
+		cards, preferred, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
 			node, pod,
 			nodeResourcesUsed,
 			gpuMap)
@@ -755,16 +795,85 @@ func TestResourceBalancedCardsForContainerGPURequest(t *testing.T) {
 	})
 }
 
+func TestGPUResourceCapacities(t *testing.T) {
+	node := getMockNode(1, 1, "card0", "card1", "card2")
+	node.Status.Allocatable["gpu.intel.com/memory.max"] = resource.MustParse("24Gi")
+
+	Convey("When gpus have no capacity labels, the node capacity is divided evenly", t, func() {
+		capacities := getGPUResourceCapacities(node, []string{"card0", "card1", "card2"})
+		So(capacities["card1"]["gpu.intel.com/memory.max"], ShouldEqual, 8<<30)
+		So(capacities["card2"]["gpu.intel.com/i915"], ShouldEqual, 1)
+	})
+
+	Convey("When a gpu has a capacity label, the rest is divided to the other gpus", t, func() {
+		node.Labels["gpu.intel.com/card0.memory.max"] = "16Gi"
+		capacities := getGPUResourceCapacities(node, []string{"card0", "card1", "card2"})
+		So(capacities["card0"]["gpu.intel.com/memory.max"], ShouldEqual, 16<<30)
+		So(capacities["card1"]["gpu.intel.com/memory.max"], ShouldEqual, 4<<30)
+		So(capacities["card2"]["gpu.intel.com/memory.max"], ShouldEqual, 4<<30)
+	})
+
+	Convey("When a capacity label is bad, the gpu gets its share of the node capacity", t, func() {
+		node.Labels["gpu.intel.com/card0.memory.max"] = "lots"
+		capacities := getGPUResourceCapacities(node, []string{"card0", "card1", "card2"})
+		So(capacities["card0"]["gpu.intel.com/memory.max"], ShouldEqual, 8<<30)
+	})
+
+	Convey("When the labels exceed the node capacity, the unlabeled gpus get nothing", t, func() {
+		node.Labels["gpu.intel.com/card0.memory.max"] = "16Gi"
+		node.Labels["gpu.intel.com/card1.memory.max"] = "16Gi"
+		capacities := getGPUResourceCapacities(node, []string{"card0", "card1", "card2"})
+		So(capacities["card2"]["gpu.intel.com/memory.max"], ShouldEqual, 0)
+	})
+}
+
+func TestHeterogeneousCardsForContainerGPURequest(t *testing.T) {
+	gas := getEmptyExtender()
+	node := getMockNode(1, 1, "card0", "card1")
+	pod := getFakePod()
+
+	gpuCapacities := nodeResources{
+		"card0": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/memory.max": 4000},
+		"card1": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/memory.max": 16000},
+	}
+	gpuMap := map[string]bool{"card0": true, "card1": true}
+
+	Convey("When the request only fits the bigger gpu, the bigger gpu is selected", t, func() {
+		containerRequest := resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/memory.max": 8000}
+		nodeResourcesUsed := nodeResources{"card0": resourceMap{}, "card1": resourceMap{}}
+
+		cards, _, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
+			node, pod, nodeResourcesUsed, gpuMap)
+
+		So(err, ShouldBeNil)
+		So(cards, ShouldResemble, []string{"card1"})
+	})
+
+	Convey("When the request fits no gpu, the shortage is told against the capacity of each gpu", t, func() {
+		containerRequest := resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/memory.max": 20000}
+		nodeResourcesUsed := nodeResources{"card0": resourceMap{}, "card1": resourceMap{}}
+
+		_, _, err := gas.getCardsForContainerGPURequest(containerRequest, gpuCapacities,
+			node, pod, nodeResourcesUsed, gpuMap)
+
+		var failure *fitFailure
+		So(errors.As(err, &failure), ShouldBeTrue)
+		So(failure.gpus["card0"], ShouldContainSubstring, "free 4000")
+		So(failure.gpus["card1"], ShouldContainSubstring, "free 16000")
+	})
+}
+
 func TestRunSchedulingLogicWithMultiContainerTileResourceReq(t *testing.T) {
 	pod := getFakePod()
 
 	clientset := fake.NewSimpleClientset(pod)
-	gas := NewGASExtender(clientset, false, false, "tiles")
+	gas := NewGASExtender(clientset, false, false, "tiles", preferredCardPolicy, 0)
 	mockNode := getMockNode(4, 4, "card0")
 
 	pod.Spec = *getMockPodSpecMultiCont()
 
 	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
 	origCacheAPI := iCache
 	iCache = &mockCache
 
@@ -838,8 +947,9 @@ func TestTileDisablingDeschedulingAndPreference(t *testing.T) {
 	pod := getFakePod()
 
 	clientset := fake.NewSimpleClientset(pod)
-	gas := NewGASExtender(clientset, false, false, "")
+	gas := NewGASExtender(clientset, false, false, "", preferredCardPolicy, 0)
 	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
 	origCacheAPI := iCache
 	iCache = &mockCache
 	args := extender.BindingArgs{}
@@ -1022,8 +1132,9 @@ func TestTileSanitation(t *testing.T) {
 	pod.Spec = *getMockPodSpecWithTile(1)
 
 	clientset := fake.NewSimpleClientset(pod)
-	gas := NewGASExtender(clientset, false, false, "")
+	gas := NewGASExtender(clientset, false, false, "", preferredCardPolicy, 0)
 	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
 	origCacheAPI := iCache
 	iCache = &mockCache
 	args := extender.BindingArgs{}
@@ -1079,8 +1190,9 @@ func TestFilterWithDisabledTiles(t *testing.T) {
 	pod.Spec = *getMockPodSpecWithTile(1)
 
 	clientset := fake.NewSimpleClientset(pod)
-	gas := NewGASExtender(clientset, false, false, "")
+	gas := NewGASExtender(clientset, false, false, "", preferredCardPolicy, 0)
 	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
 	origCacheAPI := iCache
 	iCache = &mockCache
 	args := extender.BindingArgs{}
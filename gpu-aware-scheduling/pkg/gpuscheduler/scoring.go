@@ -0,0 +1,429 @@
+package gpuscheduler
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/extender"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// maxPriority is the highest score an extender may give for a node (MaxExtenderPriority in kube-scheduler).
+	maxPriority          = 10
+	policyAnnotationName = "gas-policy"
+	policyArgDelimiter   = ":"
+	binpackPolicy        = "binpack"
+	spreadPolicy         = "spread"
+	tilesPolicy          = "tiles"
+	balancedPolicy       = "balanced"
+	preferredCardPolicy  = "preferred-card"
+	// defaultScoringPolicy is used when the extender is given no known policy. It keeps the gpu order GAS
+	// has always used: the gas-prefer-gpu gpu first, then the other gpus in name order.
+	defaultScoringPolicy = preferredCardPolicy
+	weightDelimiter      = "="
+	defaultWeight        = 1.0
+)
+
+// Errors.
+var (
+	errUnknownPolicy = errors.New("unknown scoring policy")
+)
+
+// scoringPolicy decides the order in which the gpus of a node are tried for the containers of a pod,
+// and how the node is scored for the pod once the pod is fitted into it.
+type scoringPolicy interface {
+	// policyType returns the name with which the policy is selected.
+	policyType() string
+	// arrangeGPUs reorders the given sorted gpu names into the order in which they are tried for a container.
+	// It returns true if the first gpu is the preferred gpu of the node.
+	arrangeGPUs(gpuNames []string, nodeResourcesUsed nodeResources, gpuCapacities nodeResources,
+		node *v1.Node, arg string) bool
+	// score returns the score of the node fit in the range [0, maxPriority].
+	score(fit *nodeFit, arg string) int
+}
+
+// registerScoringPolicy adds the policy to the registry of the extender, after which
+// pods may select it with the policy annotation.
+func (m *GASExtender) registerScoringPolicy(policy scoringPolicy) {
+	m.scoringPolicies[policy.policyType()] = policy
+}
+
+// isScoringPolicyRegistered returns true if the policy name, without its argument, is registered.
+func (m *GASExtender) isScoringPolicyRegistered(policyName string) bool {
+	name, _ := splitPolicyName(policyName)
+	_, ok := m.scoringPolicies[name]
+
+	return ok
+}
+
+// splitPolicyName splits a policy name like "balanced:tiles" to the policy type and its argument.
+func splitPolicyName(policyName string) (name, arg string) {
+	parts := strings.SplitN(policyName, policyArgDelimiter, maxLabelParts)
+	if len(parts) == maxLabelParts {
+		return parts[0], parts[1]
+	}
+
+	return parts[0], ""
+}
+
+// podScoringPolicy returns the scoring policy and its argument for the pod. The policy annotation of the
+// pod is used if it names a registered policy, otherwise the default of the extender applies.
+func (m *GASExtender) podScoringPolicy(pod *v1.Pod) (scoringPolicy, string) {
+	policyName := m.defaultPolicy
+
+	if m.balancedResource != "" {
+		policyName = balancedPolicy + policyArgDelimiter + m.balancedResource
+	}
+
+	if annotation, ok := pod.Annotations[policyAnnotationName]; ok {
+		if m.isScoringPolicyRegistered(annotation) {
+			policyName = annotation
+		} else {
+			klog.Warningf("pod %v has an unknown scoring policy %v, using %v", pod.Name, annotation, policyName)
+		}
+	}
+
+	name, arg := splitPolicyName(policyName)
+
+	return m.scoringPolicies[name], arg
+}
+
+// cardUtilization returns the average share of the capacity of a card which is in use.
+// Resources without capacity are ignored.
+func cardUtilization(used, capacity resourceMap) float64 {
+	total := 0.0
+	count := 0
+
+	for resName, resCapacity := range capacity {
+		if resCapacity <= 0 {
+			continue
+		}
+
+		total += float64(used[resName]) / float64(resCapacity)
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return total / float64(count)
+}
+
+// cardsUtilization returns the average utilization of the given cards.
+func cardsUtilization(cards []string, resourcesUsed nodeResources, gpuCapacities nodeResources) float64 {
+	if len(cards) == 0 {
+		return 0
+	}
+
+	total := 0.0
+
+	for _, card := range cards {
+		total += cardUtilization(resourcesUsed[card], gpuCapacities[card])
+	}
+
+	return total / float64(len(cards))
+}
+
+// podCards returns the distinct cards used by any of the containers.
+func podCards(containerCards [][]string) []string {
+	cards := []string{}
+
+	for _, containerCards := range containerCards {
+		for _, card := range containerCards {
+			if !containsString(cards, card) {
+				cards = append(cards, card)
+			}
+		}
+	}
+
+	return cards
+}
+
+// toScore converts a ratio in the range [0,1] to a node score.
+func toScore(ratio float64) int {
+	return int(math.Round(math.Max(0, math.Min(1, ratio)) * maxPriority))
+}
+
+// sortGPUNamesByUtilization sorts the gpu names by their utilization, keeping the order of equally used gpus.
+func sortGPUNamesByUtilization(gpuNames []string, nodeResourcesUsed nodeResources,
+	gpuCapacities nodeResources, mostUsedFirst bool) {
+	utilization := map[string]float64{}
+	for _, gpuName := range gpuNames {
+		utilization[gpuName] = cardUtilization(nodeResourcesUsed[gpuName], gpuCapacities[gpuName])
+	}
+
+	sort.SliceStable(gpuNames, func(i, j int) bool {
+		if mostUsedFirst {
+			return utilization[gpuNames[i]] > utilization[gpuNames[j]]
+		}
+
+		return utilization[gpuNames[i]] < utilization[gpuNames[j]]
+	})
+}
+
+// binpack places containers to the most utilized gpus and prefers nodes in which the pod
+// would land on the most utilized gpus.
+type binpack struct{}
+
+func (p *binpack) policyType() string {
+	return binpackPolicy
+}
+
+func (p *binpack) arrangeGPUs(gpuNames []string, nodeResourcesUsed nodeResources, gpuCapacities nodeResources,
+	_ *v1.Node, _ string) bool {
+	sortGPUNamesByUtilization(gpuNames, nodeResourcesUsed, gpuCapacities, true)
+
+	return false
+}
+
+func (p *binpack) score(fit *nodeFit, _ string) int {
+	return toScore(cardsUtilization(podCards(fit.containerCards), fit.resourcesUsed, fit.gpuCapacities))
+}
+
+// spread places containers to the least utilized gpus and prefers nodes which would have the most
+// gpu resources left unused.
+type spread struct{}
+
+func (p *spread) policyType() string {
+	return spreadPolicy
+}
+
+func (p *spread) arrangeGPUs(gpuNames []string, nodeResourcesUsed nodeResources, gpuCapacities nodeResources,
+	_ *v1.Node, _ string) bool {
+	sortGPUNamesByUtilization(gpuNames, nodeResourcesUsed, gpuCapacities, false)
+
+	return false
+}
+
+func (p *spread) score(fit *nodeFit, _ string) int {
+	return toScore(1 - cardsUtilization(getSortedGPUNamesForNode(fit.resourcesUsed),
+		fit.resourcesUsed, fit.gpuCapacities))
+}
+
+// tiles places containers to the gpus with the most used tiles and prefers nodes which would have
+// the fewest gpus with partially used tiles.
+type tiles struct{}
+
+func (p *tiles) policyType() string {
+	return tilesPolicy
+}
+
+func (p *tiles) arrangeGPUs(gpuNames []string, nodeResourcesUsed nodeResources, _ nodeResources,
+	_ *v1.Node, _ string) bool {
+	sort.SliceStable(gpuNames, func(i, j int) bool {
+		return nodeResourcesUsed[gpuNames[i]][gpuTileResource] > nodeResourcesUsed[gpuNames[j]][gpuTileResource]
+	})
+
+	return false
+}
+
+func (p *tiles) score(fit *nodeFit, _ string) int {
+	if len(fit.resourcesUsed) == 0 {
+		return 0
+	}
+
+	fragmented := 0
+	hasTiles := false
+
+	for gpuName, used := range fit.resourcesUsed {
+		tileCapacity := fit.gpuCapacities[gpuName][gpuTileResource]
+		if tileCapacity <= 0 {
+			continue
+		}
+
+		hasTiles = true
+
+		if usedTiles := used[gpuTileResource]; usedTiles > 0 && usedTiles < tileCapacity {
+			fragmented++
+		}
+	}
+
+	if !hasTiles {
+		return 0
+	}
+
+	return toScore(1 - float64(fragmented)/float64(len(fit.resourcesUsed)))
+}
+
+// balanced places containers to the gpus with the least used share of the resources given as the argument,
+// and prefers nodes which would have the most of those resources left unused. The argument is a comma
+// separated list of resource names with optional weights, like "millicores=2,memory.max". With several
+// resources, the gpus are ordered by their dominant resource share, which is the biggest weighted share
+// of capacity in use over the balanced resources.
+type balanced struct{}
+
+func (p *balanced) policyType() string {
+	return balancedPolicy
+}
+
+func (p *balanced) arrangeGPUs(gpuNames []string, nodeResourcesUsed nodeResources, gpuCapacities nodeResources,
+	_ *v1.Node, arg string) bool {
+	weights := parseBalancedResources(arg)
+
+	// a single resource without a weight keeps the ordering by the used amount of the resource
+	if len(weights) == 1 && !strings.Contains(arg, weightDelimiter) {
+		arrangeGPUNamesPerResourceAvailability(nodeResourcesUsed, gpuNames, strings.TrimSpace(arg))
+
+		return false
+	}
+
+	shares := map[string]float64{}
+	for _, gpuName := range gpuNames {
+		shares[gpuName] = dominantShare(nodeResourcesUsed[gpuName], gpuCapacities[gpuName], weights)
+	}
+
+	sort.SliceStable(gpuNames, func(i, j int) bool {
+		return shares[gpuNames[i]] < shares[gpuNames[j]]
+	})
+
+	return false
+}
+
+func (p *balanced) score(fit *nodeFit, arg string) int {
+	weights := parseBalancedResources(arg)
+	gpuNames := getSortedGPUNamesForNode(fit.resourcesUsed)
+
+	if len(gpuNames) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, gpuName := range gpuNames {
+		total += dominantShare(fit.resourcesUsed[gpuName], fit.gpuCapacities[gpuName], weights)
+	}
+
+	return toScore(1 - total/float64(len(gpuNames)))
+}
+
+// parseBalancedResources parses the balanced policy argument to a map of prefixed resource names and
+// weights. The weights are normalized so that the biggest weight is one. Bad weights are logged and
+// replaced with the default weight.
+func parseBalancedResources(arg string) map[string]float64 {
+	weights := map[string]float64{}
+	maxWeight := 0.0
+
+	for _, item := range strings.Split(arg, ",") {
+		parts := strings.SplitN(strings.TrimSpace(item), weightDelimiter, maxLabelParts)
+		if parts[0] == "" {
+			continue
+		}
+
+		weight := defaultWeight
+
+		if len(parts) == maxLabelParts {
+			parsed, err := strconv.ParseFloat(parts[1], 64)
+			if err != nil || parsed <= 0 {
+				klog.Warningf("bad weight %v for balanced resource %v, using %v", parts[1], parts[0], defaultWeight)
+			} else {
+				weight = parsed
+			}
+		}
+
+		weights[gpuPrefix+parts[0]] = weight
+		maxWeight = math.Max(maxWeight, weight)
+	}
+
+	for resName := range weights {
+		weights[resName] /= maxWeight
+	}
+
+	return weights
+}
+
+// dominantShare returns the biggest weighted share of capacity in use over the given resources.
+// Resources without capacity are ignored.
+func dominantShare(used, capacity resourceMap, weights map[string]float64) float64 {
+	share := 0.0
+
+	for resName, weight := range weights {
+		resCapacity := capacity[resName]
+		if resCapacity <= 0 {
+			continue
+		}
+
+		share = math.Max(share, weight*float64(used[resName])/float64(resCapacity))
+	}
+
+	return share
+}
+
+// preferredCard places containers to the gpu named in the gas-prefer-gpu node label first, and prefers
+// nodes in which the preferred gpu gets used.
+type preferredCard struct{}
+
+func (p *preferredCard) policyType() string {
+	return preferredCardPolicy
+}
+
+func (p *preferredCard) arrangeGPUs(gpuNames []string, _ nodeResources, _ nodeResources,
+	node *v1.Node, _ string) bool {
+	if card := findNodesPreferredGPU(node); card != "" {
+		movePreferredCardToFront(gpuNames, card)
+
+		return true
+	}
+
+	return false
+}
+
+func (p *preferredCard) score(fit *nodeFit, _ string) int {
+	if fit.preferred {
+		return maxPriority
+	}
+
+	return 0
+}
+
+// prioritizeNodes scores the nodes given in the arguments for the pod. Nodes in which the pod doesn't fit
+// get the lowest score.
+func (m *GASExtender) prioritizeNodes(args *extender.Args) *extender.HostPriorityList {
+	result := extender.HostPriorityList{}
+
+	defer m.metrics.observeDuration(prioritizeVerb, time.Now())
+
+	if args.NodeNames == nil || len(*args.NodeNames) == 0 {
+		klog.Error("No nodes to prioritize. " +
+			"This should not happen, perhaps the extender is misconfigured with NodeCacheCapable == false.")
+
+		return &result
+	}
+
+	lockStart := time.Now()
+	m.rwmutex.Lock()
+	m.metrics.observeLockWait(prioritizeVerb, lockStart)
+	klog.V(l5).Infof("prioritize %v:%v from %v locked", args.Pod.Namespace, args.Pod.Name, *args.NodeNames)
+	defer m.rwmutex.Unlock()
+
+	policy, arg := m.podScoringPolicy(&args.Pod)
+
+	for _, nodeName := range *args.NodeNames {
+		score := 0
+
+		node, err := m.getNodeForName(nodeName)
+		if err == nil {
+			var fit nodeFit
+
+			fit, err = m.fitPodToNode(&args.Pod, node)
+			if err == nil {
+				score = policy.score(&fit, arg)
+			}
+		}
+
+		if err != nil {
+			klog.V(l4).Infof("node %v gets the lowest score for pod %v: %v", nodeName, args.Pod.Name, err)
+		}
+
+		result = append(result, extender.HostPriority{Host: nodeName, Score: score})
+	}
+
+	klog.V(l3).Infof("node priorities for pod %v with policy %v: %v", args.Pod.Name, policy.policyType(), result)
+
+	return &result
+}
@@ -0,0 +1,279 @@
+//go:build !validation
+// +build !validation
+
+// nolint:testpackage
+package gpuscheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/intel/platform-aware-scheduling/extender"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCardUtilization(t *testing.T) {
+	capacity := resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/millicores": 1000, "gpu.intel.com/foo": 0}
+
+	Convey("When a card is half used, utilization is a half", t, func() {
+		used := resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/millicores": 500}
+		So(cardUtilization(used, capacity), ShouldEqual, 0.5)
+	})
+
+	Convey("When there is no capacity, utilization is zero", t, func() {
+		So(cardUtilization(resourceMap{"gpu.intel.com/i915": 1}, resourceMap{}), ShouldEqual, 0)
+	})
+}
+
+func TestPolicyScores(t *testing.T) {
+	fit := nodeFit{
+		containerCards: [][]string{{"card0"}},
+		gpuCapacities: nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 4},
+			"card1": resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 4},
+			"card2": resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 4},
+			"card3": resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 4},
+		},
+		resourcesUsed: nodeResources{
+			"card0": resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 4},
+			"card1": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/tiles": 2},
+			"card2": resourceMap{},
+			"card3": resourceMap{},
+		},
+	}
+
+	Convey("When scoring with binpack, the utilization of the selected card is used", t, func() {
+		So((&binpack{}).score(&fit, ""), ShouldEqual, maxPriority)
+	})
+
+	Convey("When scoring with spread, the free resources of the node are used", t, func() {
+		So((&spread{}).score(&fit, ""), ShouldEqual, 6)
+	})
+
+	Convey("When scoring with tiles, the partially used gpus reduce the score", t, func() {
+		So((&tiles{}).score(&fit, ""), ShouldEqual, 8)
+	})
+
+	Convey("When scoring with balanced, only the named resource is used", t, func() {
+		for _, capacity := range fit.gpuCapacities {
+			capacity["gpu.intel.com/millicores"] = 1000
+		}
+		fit.resourcesUsed["card2"]["gpu.intel.com/millicores"] = 1000
+		So((&balanced{}).score(&fit, "millicores"), ShouldEqual, 8)
+		So((&balanced{}).score(&fit, "tiles"), ShouldEqual, 6)
+	})
+
+	Convey("When scoring with preferred-card, only the use of the preferred gpu matters", t, func() {
+		So((&preferredCard{}).score(&fit, ""), ShouldEqual, 0)
+		fit.preferred = true
+		So((&preferredCard{}).score(&fit, ""), ShouldEqual, maxPriority)
+	})
+}
+
+func TestPolicyGPUArrangement(t *testing.T) {
+	nodeUsedRes := nodeResources{
+		"card0": resourceMap{"gpu.intel.com/i915": 1, "gpu.intel.com/tiles": 1},
+		"card1": resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 4},
+		"card2": resourceMap{},
+	}
+	capacity := resourceMap{"gpu.intel.com/i915": 2, "gpu.intel.com/tiles": 4}
+	gpuCapacities := nodeResources{"card0": capacity, "card1": capacity, "card2": capacity}
+	node := getMockNode(2, 4, "card0")
+
+	Convey("When arranging gpus with binpack, the most used gpu is at front", t, func() {
+		gpuNames := []string{"card0", "card1", "card2"}
+		(&binpack{}).arrangeGPUs(gpuNames, nodeUsedRes, gpuCapacities, node, "")
+		So(gpuNames, ShouldResemble, []string{"card1", "card0", "card2"})
+	})
+
+	Convey("When arranging gpus with spread, the least used gpu is at front", t, func() {
+		gpuNames := []string{"card0", "card1", "card2"}
+		(&spread{}).arrangeGPUs(gpuNames, nodeUsedRes, gpuCapacities, node, "")
+		So(gpuNames, ShouldResemble, []string{"card2", "card0", "card1"})
+	})
+
+	Convey("When arranging gpus with preferred-card, the preferred gpu is at front", t, func() {
+		gpuNames := []string{"card0", "card1", "card2"}
+		So((&preferredCard{}).arrangeGPUs(gpuNames, nodeUsedRes, gpuCapacities, node, ""), ShouldBeFalse)
+		So(gpuNames, ShouldResemble, []string{"card0", "card1", "card2"})
+
+		node.Labels["telemetry.aware.scheduling.policy/gas-prefer-gpu"] = "card2"
+		So((&preferredCard{}).arrangeGPUs(gpuNames, nodeUsedRes, gpuCapacities, node, ""), ShouldBeTrue)
+		So(gpuNames[0], ShouldEqual, "card2")
+	})
+}
+
+func TestParseBalancedResources(t *testing.T) {
+	Convey("When parsing resources with weights, the weights are normalized", t, func() {
+		weights := parseBalancedResources("millicores=4, memory.max=2,tiles")
+		So(weights, ShouldResemble, map[string]float64{
+			"gpu.intel.com/millicores": 1, "gpu.intel.com/memory.max": 0.5, "gpu.intel.com/tiles": 0.25,
+		})
+	})
+
+	Convey("When parsing resources with bad weights, the default weight is used", t, func() {
+		weights := parseBalancedResources("millicores=foo,memory.max=-1")
+		So(weights, ShouldResemble, map[string]float64{
+			"gpu.intel.com/millicores": 1, "gpu.intel.com/memory.max": 1,
+		})
+	})
+}
+
+func TestMultiResourceBalancing(t *testing.T) {
+	capacity := resourceMap{"gpu.intel.com/millicores": 1000, "gpu.intel.com/memory.max": 8000}
+	gpuCapacities := nodeResources{"card0": capacity, "card1": capacity, "card2": capacity}
+	nodeUsedRes := nodeResources{
+		"card0": resourceMap{"gpu.intel.com/millicores": 600, "gpu.intel.com/memory.max": 1000},
+		"card1": resourceMap{"gpu.intel.com/millicores": 200, "gpu.intel.com/memory.max": 6000},
+		"card2": resourceMap{"gpu.intel.com/millicores": 400, "gpu.intel.com/memory.max": 2000},
+	}
+	node := getMockNode(1, 1, "card0")
+
+	Convey("When balancing millicores only, the gpu with the least used millicores is at front", t, func() {
+		gpuNames := []string{"card0", "card1", "card2"}
+		(&balanced{}).arrangeGPUs(gpuNames, nodeUsedRes, gpuCapacities, node, "millicores")
+		So(gpuNames, ShouldResemble, []string{"card1", "card2", "card0"})
+	})
+
+	Convey("When balancing millicores and memory, the gpu with the lowest dominant share is at front", t, func() {
+		gpuNames := []string{"card0", "card1", "card2"}
+		(&balanced{}).arrangeGPUs(gpuNames, nodeUsedRes, gpuCapacities, node, "millicores,memory.max")
+		So(gpuNames, ShouldResemble, []string{"card2", "card0", "card1"})
+	})
+
+	Convey("When balancing with weights, the weighted shares decide the order", t, func() {
+		gpuNames := []string{"card0", "card1", "card2"}
+		(&balanced{}).arrangeGPUs(gpuNames, nodeUsedRes, gpuCapacities, node, "millicores=4,memory.max=1")
+		So(gpuNames, ShouldResemble, []string{"card1", "card2", "card0"})
+	})
+
+	Convey("When scoring with multiple resources, the dominant shares are used", t, func() {
+		fit := nodeFit{gpuCapacities: gpuCapacities, resourcesUsed: nodeUsedRes}
+		So((&balanced{}).score(&fit, "millicores,memory.max"), ShouldEqual, 4)
+	})
+}
+
+func TestPodScoringPolicy(t *testing.T) {
+	gas := getDummyExtender()
+	pod := getFakePod()
+
+	Convey("When pod has no policy annotation, the default policy is used", t, func() {
+		policy, arg := gas.podScoringPolicy(pod)
+		So(policy.policyType(), ShouldEqual, preferredCardPolicy)
+		So(arg, ShouldEqual, "")
+	})
+
+	Convey("When pod has a policy annotation with an argument, the annotation is used", t, func() {
+		pod.Annotations[policyAnnotationName] = "balanced:millicores"
+		policy, arg := gas.podScoringPolicy(pod)
+		So(policy.policyType(), ShouldEqual, balancedPolicy)
+		So(arg, ShouldEqual, "millicores")
+	})
+
+	Convey("When pod has an unknown policy annotation, the default policy is used", t, func() {
+		pod.Annotations[policyAnnotationName] = "foo"
+		policy, _ := gas.podScoringPolicy(pod)
+		So(policy.policyType(), ShouldEqual, preferredCardPolicy)
+	})
+
+	Convey("When the extender is created with an unknown default policy, preferred-card is used", t, func() {
+		So(NewGASExtender(nil, false, false, "", "foo", 0).defaultPolicy, ShouldEqual, preferredCardPolicy)
+	})
+}
+
+func TestPrioritizeNodes(t *testing.T) {
+	gas := getEmptyExtender()
+	defaultGAS := NewGASExtender(fake.NewSimpleClientset(), false, false, "", "", 0)
+	mockCache := MockCacheAPI{}
+	mockCache.On("GetNodeCardPods", mock.Anything, mock.Anything).Return(cardPods{})
+	origCacheAPI := iCache
+	iCache = &mockCache
+
+	node1 := getMockNode(2, 0, "card0", "card1")
+	node1.Name = "node1"
+	node1.Labels["gpu.intel.com/cards"] = "card0.card1"
+	node2 := getMockNode(2, 0, "card0", "card1")
+	node2.Name = "node2"
+	node2.Labels["gpu.intel.com/cards"] = "card0.card1"
+
+	args := extender.Args{Pod: v1.Pod{Spec: *getMockPodSpec()}, NodeNames: &[]string{"node1", "node2", "node3"}}
+
+	setupMocks := func() {
+		mockCache.On("FetchNode", mock.Anything, "node1").Return(node1, nil).Once()
+		mockCache.On("FetchNode", mock.Anything, "node2").Return(node2, nil).Once()
+		mockCache.On("FetchNode", mock.Anything, "node3").Return(nil, errMock).Once()
+		mockCache.On("GetNodeResourceStatus", mock.Anything, "node1").Return(
+			nodeResources{"card0": resourceMap{"gpu.intel.com/i915": 1}}).Once()
+		mockCache.On("GetNodeResourceStatus", mock.Anything, "node2").Return(nodeResources{}).Once()
+	}
+
+	Convey("When there are no nodes to prioritize", t, func() {
+		result := gas.prioritizeNodes(&extender.Args{})
+		So(len(*result), ShouldEqual, 0)
+	})
+
+	Convey("When prioritizing with binpack, the node with the most used gpu wins", t, func() {
+		args.Pod.Annotations = map[string]string{policyAnnotationName: binpackPolicy}
+		setupMocks()
+		result := gas.prioritizeNodes(&args)
+		So(*result, ShouldResemble, extender.HostPriorityList{
+			{Host: "node1", Score: 10}, {Host: "node2", Score: 5}, {Host: "node3", Score: 0},
+		})
+	})
+
+	Convey("When prioritizing with spread, the node with the least used gpus wins", t, func() {
+		args.Pod.Annotations = map[string]string{policyAnnotationName: spreadPolicy}
+		setupMocks()
+		result := gas.prioritizeNodes(&args)
+		So(*result, ShouldResemble, extender.HostPriorityList{
+			{Host: "node1", Score: 5}, {Host: "node2", Score: 8}, {Host: "node3", Score: 0},
+		})
+	})
+
+	Convey("When prioritizing with the default policy, the node whose preferred gpu gets used wins", t, func() {
+		args.Pod.Annotations = map[string]string{}
+		node2.Labels[tasNSPrefix+"policy/"+gpuPreferenceLabel] = "card1"
+		setupMocks()
+		result := defaultGAS.prioritizeNodes(&args)
+		So(*result, ShouldResemble, extender.HostPriorityList{
+			{Host: "node1", Score: 0}, {Host: "node2", Score: 10}, {Host: "node3", Score: 0},
+		})
+	})
+
+	iCache = origCacheAPI
+}
+
+func TestPrioritize(t *testing.T) {
+	gas := getEmptyExtender()
+
+	Convey("When Prioritize is called with a bad request body", t, func() {
+		w := httptest.NewRecorder()
+		request, err := http.NewRequestWithContext(context.Background(),
+			"POST", "http://foo/bar", bytes.NewBuffer([]byte("foo")))
+		So(err, ShouldBeNil)
+		gas.Prioritize(w, request)
+		So(w.Code, ShouldEqual, http.StatusNotFound)
+	})
+
+	Convey("When Prioritize is called without nodes", t, func() {
+		w := httptest.NewRecorder()
+		content, err := json.Marshal(extender.Args{})
+		So(err, ShouldBeNil)
+		request, err := http.NewRequestWithContext(context.Background(),
+			"POST", "http://foo/bar", bytes.NewBuffer(content))
+		So(err, ShouldBeNil)
+		gas.Prioritize(w, request)
+		So(w.Code, ShouldEqual, http.StatusOK)
+
+		result := extender.HostPriorityList{}
+		So(json.NewDecoder(w.Body).Decode(&result), ShouldBeNil)
+		So(len(result), ShouldEqual, 0)
+	})
+}
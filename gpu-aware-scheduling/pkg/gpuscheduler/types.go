@@ -1,6 +1,8 @@
 package gpuscheduler
 
 import (
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -12,10 +14,19 @@ import (
 type CacheAPI interface {
 	NewCache(kubernetes.Interface) *Cache
 	FetchNode(cache *Cache, nodeName string) (*v1.Node, error)
+	FetchNodes(cache *Cache) ([]*v1.Node, error)
 	FetchPod(cache *Cache, podNS, podName string) (*v1.Pod, error)
 	GetNodeResourceStatus(cache *Cache, nodeName string) nodeResources
 	GetNodeTileStatus(cache *Cache, nodeName string) nodeTiles
+	GetNodeCardPods(cache *Cache, nodeName string) cardPods
 	AdjustPodResourcesL(cache *Cache, pod *v1.Pod, adj bool, annotation, tileAnnotation, nodeName string) error
+	IsPodGroupMemberBound(cache *Cache, group string, pod *v1.Pod) bool
+	IsPodGroupMemberReserved(cache *Cache, group string, pod *v1.Pod) bool
+	ReservePodGroupMemberL(cache *Cache, group string, size int, timeout time.Duration,
+		member podGroupMember) ([]podGroupMember, error)
+	GetReconcileProblems(cache *Cache) []reconcileProblem
+	IsReady(cache *Cache) bool
+	RebuildL(cache *Cache) error
 }
 
 // InternalCacheAPI has the mocked interface of Cache internals.
@@ -24,9 +24,14 @@ type DescheduledTilesMap map[string][]int
 type PreferredTilesMap map[string][]int
 
 func containerRequests(pod *v1.Pod) []resourceMap {
+	return resourceRequests(pod.Spec.Containers)
+}
+
+// resourceRequests returns the gpu resource requests of the given containers.
+func resourceRequests(containers []v1.Container) []resourceMap {
 	allResources := []resourceMap{}
 
-	for _, container := range pod.Spec.Containers {
+	for _, container := range containers {
 		rm := resourceMap{}
 
 		for name, quantity := range container.Resources.Requests {
@@ -129,6 +134,26 @@ func combineMappings(source map[string][]int, dest map[string][]int) {
 	}
 }
 
+// copyTileMapping returns a copy of the card to tile-index map.
+func copyTileMapping(source map[string][]int) map[string][]int {
+	dest := map[string][]int{}
+
+	combineMappings(source, dest)
+
+	return dest
+}
+
+// appendNewTiles appends the tiles which are not in the slice yet.
+func appendNewTiles(tiles []int, newTiles []int) []int {
+	for _, tile := range newTiles {
+		if found, _ := containsInt(tiles, tile); !found {
+			tiles = append(tiles, tile)
+		}
+	}
+
+	return tiles
+}
+
 // creates a card to tile-index map which are in either state "disabled" or "descheduled".
 func createDisabledTileMapping(labels map[string]string) map[string][]int {
 	dis, des, _ := createTileMapping(labels)
@@ -148,14 +173,16 @@ func createDisabledAndPreferredTileMapping(labels map[string]string) (
 	return dis, pref
 }
 
-func sanitizeTiles(tilesMap DisabledTilesMap, tilesPerGpu int) DisabledTilesMap {
+// sanitizeTiles drops the tiles which are beyond the tile capacity of their card.
+func sanitizeTiles(tilesMap DisabledTilesMap, gpuCapacities nodeResources) DisabledTilesMap {
 	sanitized := DisabledTilesMap{}
 
 	for card, tiles := range tilesMap {
 		stiles := []int{}
+		tileCapacity := int(gpuCapacities[card][gpuTileResource])
 
 		for _, tile := range tiles {
-			if tile < tilesPerGpu {
+			if tile < tileCapacity {
 				stiles = append(stiles, tile)
 			} else {
 				klog.Warningf("skipping a non existing tile: %s, tile %d", card, tile)
@@ -252,7 +279,7 @@ func hasGPUResources(pod *v1.Pod) bool {
 		}
 	}
 
-	return false
+	return hasInitContainerGPURequests(pod)
 }
 
 func isCompletedPod(pod *v1.Pod) bool {
@@ -317,7 +344,7 @@ func reorderPreferredTilesFirst(tiles []int, preferred []int) []int {
 func convertPodTileAnnotationToCardTileMap(podTileAnnotation string) map[string]bool {
 	cardTileIndices := make(map[string]bool)
 
-	containerCardList := strings.Split(podTileAnnotation, "|")
+	containerCardList := allContainerAnnotations(podTileAnnotation)
 
 	for _, contAnnotation := range containerCardList {
 		cardTileList := strings.Split(contAnnotation, ",")
@@ -226,10 +226,20 @@ func TestSanitizeTiles(t *testing.T) {
 	disabled := DisabledTilesMap{"card0": {0, 3, 4}, "card1": {8, 9}}
 
 	Convey("When sanitizing tiles", t, func() {
-		disabled = sanitizeTiles(disabled, 4)
+		disabled = sanitizeTiles(disabled, nodeResources{
+			"card0": resourceMap{"gpu.intel.com/tiles": 4}, "card1": resourceMap{"gpu.intel.com/tiles": 4},
+		})
 		So(disabled["card0"], ShouldResemble, []int{0, 3})
 		So(disabled["card1"], ShouldResemble, []int{})
 	})
+
+	Convey("When sanitizing tiles of gpus with different tile counts", t, func() {
+		disabled = sanitizeTiles(DisabledTilesMap{"card0": {0, 3}, "card1": {0, 3}}, nodeResources{
+			"card0": resourceMap{"gpu.intel.com/tiles": 4}, "card1": resourceMap{"gpu.intel.com/tiles": 2},
+		})
+		So(disabled["card0"], ShouldResemble, []int{0, 3})
+		So(disabled["card1"], ShouldResemble, []int{0})
+	})
 }
 
 func TestConcatenateSplitLabel(t *testing.T) {
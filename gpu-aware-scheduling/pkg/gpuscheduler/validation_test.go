@@ -10,6 +10,7 @@
 package gpuscheduler
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
@@ -46,11 +47,11 @@ func TestValidation(t *testing.T) {
 		panic(err)
 	}
 
-	gasscheduler := NewGASExtender(kubeClient, true, true)
+	gasscheduler := NewGASExtender(kubeClient, true, true, "", preferredCardPolicy, 5*time.Minute)
 	sch := extender.Server{Scheduler: gasscheduler}
 	c := make(chan bool)
 	go preStopServer(c)
-	go sch.StartServer(*port, *certFile, *keyFile, *caFile, *unsafe)
+	go func() { _ = sch.StartServer(context.Background(), *port, *certFile, *keyFile, *caFile, *unsafe) }()
 	<-c
 }
 
@@ -17,8 +17,10 @@ import (
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/dontschedule"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/labeling"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/scheduleonmetric"
-	telemetrypolicyclient "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/client/v1alpha1"
+	telemetrypolicyclient "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/client/v1beta1"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetryscheduler"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/webhook"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -29,27 +31,62 @@ import (
 	tascache "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
 )
 
+//leaderElectionConfig selects whether the TAS replicas elect a leader for strategy enforcement, and the lease used.
+type leaderElectionConfig struct {
+	enabled   bool
+	namespace string
+	id        string
+}
+
 func main() {
-	var kubeConfig, port, certFile, keyFile, caFile, syncPeriod string
+	var kubeConfig, port, healthPort, certFile, keyFile, caFile, syncPeriod, statusPeriod string
+	var webhookPort, webhookCertFile, webhookKeyFile string
+	leaderElection := leaderElectionConfig{}
 	klog.InitFlags(nil)
 	flag.StringVar(&kubeConfig, "kubeConfig", "/root/.kube/config", "location of kubernetes config file")
 	flag.StringVar(&port, "port", "9001", "port on which the scheduler extender will listen")
+	flag.StringVar(&healthPort, "healthPort", "", "port on which the health endpoints are also served over plain http, disabled if empty")
 	flag.StringVar(&certFile, "cert", "/etc/kubernetes/pki/ca.crt", "cert file extender will use for authentication")
 	flag.StringVar(&keyFile, "key", "/etc/kubernetes/pki/ca.key", "key file extender will use for authentication")
 	flag.StringVar(&caFile, "cacert", "/etc/kubernetes/pki/ca.crt", "ca file extender will use for authentication")
+	flag.StringVar(&webhookPort, "webhookPort", "", "port on which the policy admission and conversion webhooks listen, disabled if empty")
+	flag.StringVar(&webhookCertFile, "webhookCert", "/etc/kubernetes/pki/ca.crt", "cert file of the policy webhooks")
+	flag.StringVar(&webhookKeyFile, "webhookKey", "/etc/kubernetes/pki/ca.key", "key file of the policy webhooks")
 	flag.StringVar(&syncPeriod, "syncPeriod", "5s", "length of time in seconds between metrics updates")
+	flag.StringVar(&statusPeriod, "statusPeriod", "30s", "length of time between policy status updates")
+	flag.BoolVar(&leaderElection.enabled, "leaderElect", false, "elect a leader among the replicas, only the leader enforces strategies")
+	flag.StringVar(&leaderElection.namespace, "leaderElectionNamespace", "default", "namespace of the leader election lease")
+	flag.StringVar(&leaderElection.id, "leaderElectionID", "telemetry-aware-scheduling", "name of the leader election lease")
 	flag.Parse()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	cache := tascache.NewAutoUpdatingCache()
+	prometheus.MustRegister(cache)
 	tscheduler := telemetryscheduler.NewMetricsExtender(cache)
-	sch := extender.Server{Scheduler: tscheduler}
-	go sch.StartServer(port, certFile, keyFile, caFile, false)
-	tasController(kubeConfig, syncPeriod, cache)
+	sch := extender.Server{Scheduler: tscheduler, HealthPort: healthPort}
+	go tasController(ctx, kubeConfig, syncPeriod, statusPeriod, cache, leaderElection)
+	if webhookPort != "" {
+		go func() {
+			err := webhook.StartServer(ctx, webhookPort, webhookCertFile, webhookKeyFile)
+			if err != nil {
+				klog.V(2).InfoS("Policy webhook server stopped with an error", "component", "webhook")
+				klog.Exit(err.Error())
+			}
+		}()
+	}
+	err := sch.StartServer(ctx, port, certFile, keyFile, caFile, false)
+	stop()
+	if err != nil {
+		klog.V(2).InfoS("Scheduler extender server stopped with an error", "component", "extender")
+		klog.Exit(err.Error())
+	}
 	klog.Flush()
 }
 
 //tasController The controller load the TAS policy/strategies and places them into a local cache that is available
-//to all TAS components. It also monitors the current state of policies.
-func tasController(kubeConfig string, syncPeriod string, cache *tascache.AutoUpdatingCache) {
+//to all TAS components. It also monitors the current state of policies until the context is done. With leader
+//election, every replica monitors the policies but only the leader enforces the strategies and writes policy statuses.
+func tasController(parent context.Context, kubeConfig string, syncPeriod string, statusPeriod string,
+	cache *tascache.AutoUpdatingCache, leaderElection leaderElectionConfig) {
 	defer func() {
 		err := recover()
 		if err != nil {
@@ -66,6 +103,11 @@ func tasController(kubeConfig string, syncPeriod string, cache *tascache.AutoUpd
 		klog.V(2).InfoS("Sync problems in Parsing", "component", "controller")
 		klog.Exit(err.Error())
 	}
+	statusDuration, err := time.ParseDuration(statusPeriod)
+	if err != nil {
+		klog.V(2).InfoS("Status period problems in Parsing", "component", "controller")
+		klog.Exit(err.Error())
+	}
 	metricsClient := metrics.NewClient(clientConfig)
 	telpolicyClient, _, err := telemetrypolicyclient.NewRest(*clientConfig)
 	if err != nil {
@@ -76,7 +118,7 @@ func tasController(kubeConfig string, syncPeriod string, cache *tascache.AutoUpd
 	initialData := map[string]interface{}{}
 	go cache.PeriodicUpdate(*metricTicker, metricsClient, initialData)
 	enforcerTicker := time.NewTicker(syncDuration)
-	ctx, cancelFunc := context.WithCancel(context.Background())
+	ctx, cancelFunc := context.WithCancel(parent)
 	defer cancelFunc()
 	enfrcr := strategy.NewEnforcer(kubeClient)
 	cont := controller.TelemetryPolicyController{
@@ -88,10 +130,23 @@ func tasController(kubeConfig string, syncPeriod string, cache *tascache.AutoUpd
 	enfrcr.RegisterStrategyType(&scheduleonmetric.Strategy{})
 	enfrcr.RegisterStrategyType(&dontschedule.Strategy{})
 	enfrcr.RegisterStrategyType(&labeling.Strategy{})
+	if leaderElection.enabled {
+		identity, err := os.Hostname()
+		if err != nil {
+			klog.V(2).InfoS("Hostname for leader election unavailable", "component", "controller")
+			klog.Exit(err.Error())
+		}
+		err = enfrcr.StartLeaderElection(ctx, leaderElection.namespace, leaderElection.id, identity)
+		if err != nil {
+			klog.V(2).InfoS("Leader election could not be started", "component", "controller")
+			klog.Exit(err.Error())
+		}
+	}
 	go cont.Run(ctx)
 	go enfrcr.EnforceRegisteredStrategies(cache, *enforcerTicker)
-	done := make(chan os.Signal, 1)
-	catchInterrupt(done)
+	go cont.RunStatusUpdates(ctx, cache, enfrcr, statusDuration)
+	<-ctx.Done()
+	klog.V(2).InfoS("Policy controller closed ", "component", "controller")
 }
 
 func getkubeClient(kubeConfig string) (kubernetes.Interface, *rest.Config, error) {
@@ -109,9 +164,3 @@ func getkubeClient(kubeConfig string) (kubernetes.Interface, *rest.Config, error
 	}
 	return kubeClient, clientConfig, nil
 }
-
-func catchInterrupt(done chan os.Signal) {
-	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
-	<-done
-	klog.V(2).InfoS("Policy controller closed ", "component", "controller")
-}
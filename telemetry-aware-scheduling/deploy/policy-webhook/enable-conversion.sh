@@ -0,0 +1,26 @@
+#!/bin/sh
+## Switches the TASPolicy CRD from converting policies by their apiVersion to the conversion webhook of TAS. TAS has to
+## serve its webhooks, i.e. run with the webhookPort flag, before the CRD is switched, or policies can't be read.
+
+if [ $# -ne 1 ] || [ ! -f "$1" ]; then
+  echo "Usage: $(basename "$0") PATH_TO_CA_CERT" >&2
+  echo 'PATH_TO_CA_CERT is the CA certificate which signed the webhook certificate of tas-service.default.svc' >&2
+  exit 1
+fi
+
+ca_bundle=$(base64 < "$1" | tr -d '\n')
+
+kubectl patch crd taspolicies.telemetry.intel.com --type merge --patch "
+spec:
+  conversion:
+    strategy: Webhook
+    webhook:
+      conversionReviewVersions: [\"v1\"]
+      clientConfig:
+        caBundle: \"$ca_bundle\"
+        service:
+          name: tas-service
+          namespace: default
+          path: /convert
+          port: 443
+"
@@ -6,7 +6,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/instrumentation"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
 	"k8s.io/klog/v2"
 )
@@ -21,6 +23,7 @@ type AutoUpdatingCache struct {
 	concurrentCache
 	mtx       sync.RWMutex
 	metricMap map[string]int
+	updated   bool
 }
 
 //NewAutoUpdatingCache returns an empty metrics cache.
@@ -50,12 +53,21 @@ func (n *AutoUpdatingCache) updateAllMetrics(client metrics.Client) {
 		if len(name) > 0 {
 			err := n.updateMetric(client, name)
 			if err != nil {
+				instrumentation.MetricUpdateFailures.WithLabelValues(name).Inc()
 				klog.V(2).InfoS(err.Error(), "component", "controller")
 			}
 		} else {
 			delete(n.metricMap, name)
 		}
 	}
+	n.updated = true
+}
+
+//Updated returns true once the metrics in the cache have been updated at least once.
+func (n *AutoUpdatingCache) Updated() bool {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	return n.updated
 }
 
 //updateMetric updates the NodeMetricInfo object in the AutoUpdatingCache for a metric with a given name
@@ -64,13 +76,35 @@ func (n *AutoUpdatingCache) updateMetric(client metrics.Client, metricName strin
 	if err != nil {
 		return err
 	}
-	err = n.WriteMetric(metricName, metricInfo)
+	if cached, err := n.ReadMetric(metricName); err == nil {
+		metricInfo = withPreviousValues(metricInfo, cached)
+	}
+	err = n.writeMetric(metricName, metricInfo)
 	if err != nil {
 		return errors.New(err.Error() + ": " + metricName)
 	}
 	return nil
 }
 
+//withPreviousValues returns a copy of the fresh metric info in which each node metric holds the value of the
+//sample before it, taken from the cached info. A sample which is no newer than the cached one keeps the cached
+//previous value, so that metrics which are polled faster than they are scraped still see a change.
+func withPreviousValues(fresh, cached metrics.NodeMetricsInfo) metrics.NodeMetricsInfo {
+	result := make(metrics.NodeMetricsInfo, len(fresh))
+	for nodeName, nodeMetric := range fresh {
+		if old, ok := cached[nodeName]; ok {
+			if nodeMetric.Timestamp.After(old.Timestamp) {
+				previous := old.Value.DeepCopy()
+				nodeMetric.Previous = &previous
+			} else {
+				nodeMetric.Previous = old.Previous
+			}
+		}
+		result[nodeName] = nodeMetric
+	}
+	return result
+}
+
 //ReadMetric returns the NodeMetricsInfo object for the passed named metric.
 //If no metric of that name is found it returns an error.
 func (n *AutoUpdatingCache) ReadMetric(metricName string) (metrics.NodeMetricsInfo, error) {
@@ -103,6 +137,13 @@ func (n *AutoUpdatingCache) WritePolicy(namespace string, policyName string, pol
 //WriteMetric first checks if there's any data with the request and then sends the request to the cache.
 //It also increments a counter showing how many strategies are using the metric - protecting it from deletion until there are no more associated strategies.
 func (n *AutoUpdatingCache) WriteMetric(metricName string, data metrics.NodeMetricsInfo) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.writeMetric(metricName, data)
+}
+
+//writeMetric is WriteMetric for callers which already hold the lock of the metric map, like the periodic update.
+func (n *AutoUpdatingCache) writeMetric(metricName string, data metrics.NodeMetricsInfo) error {
 	payload := nilPayloadCheck(data)
 	n.add(fmt.Sprintf(metricPath, metricName), payload)
 	if payload == nil {
@@ -145,3 +186,32 @@ func nilPayloadCheck(data metrics.NodeMetricsInfo) interface{} {
 	}
 	return payload
 }
+
+//Describe sends the description of the cache metrics to prometheus.
+func (n *AutoUpdatingCache) Describe(ch chan<- *prometheus.Desc) {
+	ch <- instrumentation.MetricAgeDesc
+}
+
+//Collect sends the age of the freshest sample of each metric in the cache to prometheus.
+//Metrics which have no samples yet are skipped.
+func (n *AutoUpdatingCache) Collect(ch chan<- prometheus.Metric) {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	for name := range n.metricMap {
+		metricInfo, err := n.ReadMetric(name)
+		if err != nil {
+			continue
+		}
+		var newest time.Time
+		for _, nodeMetric := range metricInfo {
+			if nodeMetric.Timestamp.After(newest) {
+				newest = nodeMetric.Timestamp
+			}
+		}
+		if newest.IsZero() {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(instrumentation.MetricAgeDesc, prometheus.GaugeValue,
+			time.Since(newest).Seconds(), name)
+	}
+}
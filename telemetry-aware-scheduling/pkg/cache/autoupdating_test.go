@@ -6,8 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/instrumentation"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
 	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
 )
 
@@ -229,3 +232,78 @@ func TestNodeMetricsCache_DeleteMetric(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeMetricsCache_Collect(t *testing.T) {
+	n := NewAutoUpdatingCache()
+	go n.run(n.cache, map[string]interface{}{})
+	if got := testutil.CollectAndCount(n); got != 0 {
+		t.Errorf("metric ages of an empty cache = %v, want 0", got)
+	}
+	_ = n.WriteMetric("dummyMetric1", nil)
+	_ = n.WriteMetric("dummyMetric2", nil)
+	_ = n.WriteMetric("dummyMetric1", TestNodeMetricCustomInfo([]string{"node A", "node B"}, []int64{50, 30}))
+	if got := testutil.CollectAndCount(n); got != 1 {
+		t.Errorf("metric ages = %v, want 1", got)
+	}
+}
+
+func TestNodeMetricsCache_updateAllMetricsFailures(t *testing.T) {
+	n := NewAutoUpdatingCache()
+	go n.run(n.cache, map[string]interface{}{})
+	_ = n.WriteMetric("missing metric", nil)
+	before := testutil.ToFloat64(instrumentation.MetricUpdateFailures.WithLabelValues("missing metric"))
+	n.updateAllMetrics(metrics.NewDummyMetricsClient(map[string]metrics.NodeMetricsInfo{}))
+	after := testutil.ToFloat64(instrumentation.MetricUpdateFailures.WithLabelValues("missing metric"))
+	if after != before+1 {
+		t.Errorf("update failures = %v, want %v", after, before+1)
+	}
+}
+
+func TestNodeMetricsCache_Updated(t *testing.T) {
+	n := NewAutoUpdatingCache()
+	go n.run(n.cache, map[string]interface{}{})
+	if n.Updated() {
+		t.Errorf("cache updated before the first metric update")
+	}
+	n.updateAllMetrics(metrics.NewDummyMetricsClient(map[string]metrics.NodeMetricsInfo{}))
+	if !n.Updated() {
+		t.Errorf("cache not updated after the first metric update")
+	}
+}
+
+func TestNodeMetricsCache_updateMetricPrevious(t *testing.T) {
+	n := NewAutoUpdatingCache()
+	go n.run(n.cache, map[string]interface{}{})
+	sample := func(value int64, seconds int64) metrics.NodeMetricsInfo {
+		return metrics.NodeMetricsInfo{"node A": metrics.NodeMetric{
+			Value: *resource.NewQuantity(value, resource.DecimalSI), Timestamp: time.Unix(seconds, 0)}}
+	}
+	steps := []struct {
+		name         string
+		fresh        metrics.NodeMetricsInfo
+		wantPrevious *resource.Quantity
+	}{
+		{"first sample", sample(10, 100), nil},
+		{"newer sample", sample(20, 200), resource.NewQuantity(10, resource.DecimalSI)},
+		{"same sample again", sample(20, 200), resource.NewQuantity(10, resource.DecimalSI)},
+		{"next sample", sample(15, 300), resource.NewQuantity(20, resource.DecimalSI)},
+	}
+	for _, step := range steps {
+		err := n.updateMetric(metrics.NewDummyMetricsClient(map[string]metrics.NodeMetricsInfo{"dummyMetric1": step.fresh}), "dummyMetric1")
+		if err != nil {
+			t.Fatalf("%v: updateMetric() error = %v", step.name, err)
+		}
+		got, err := n.ReadMetric("dummyMetric1")
+		if err != nil {
+			t.Fatalf("%v: ReadMetric() error = %v", step.name, err)
+		}
+		previous := got["node A"].Previous
+		if (previous == nil) != (step.wantPrevious == nil) ||
+			(previous != nil && previous.Cmp(*step.wantPrevious) != 0) {
+			t.Errorf("%v: previous value = %v, want %v", step.name, previous, step.wantPrevious)
+		}
+		if step.fresh["node A"].Previous != nil {
+			t.Errorf("%v: the metric info of the client was changed", step.name)
+		}
+	}
+}
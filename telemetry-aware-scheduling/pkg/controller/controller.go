@@ -14,7 +14,9 @@ import (
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/labeling"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/scheduleonmetric"
 	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1beta1"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -39,13 +41,13 @@ func (controller *TelemetryPolicyController) Run(context context.Context) {
 func (controller *TelemetryPolicyController) watch(context context.Context) (cache.Controller, error) {
 	source := cache.NewListWatchFromClient(
 		controller,
-		telemetrypolicy.Plural,
+		v1beta1.Plural,
 		core.NamespaceAll,
 		fields.Everything(),
 	)
 	_, policyController := cache.NewInformer(
 		source,
-		&telemetrypolicy.TASPolicy{},
+		&v1beta1.TASPolicy{},
 		0,
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    controller.onAdd,
@@ -60,7 +62,7 @@ func (controller *TelemetryPolicyController) watch(context context.Context) (cac
 //onAdd fires when the controller sees a new policy in the apiserver. It adds the policy to the cache and adds each of its metrics to the cache.
 // It also adds the strategies contained in the policy to the strategy enforcer.
 func (controller *TelemetryPolicyController) onAdd(obj interface{}) {
-	pol, ok := obj.(*telemetrypolicy.TASPolicy)
+	pol, ok := internalPolicy(obj)
 	if !ok {
 		klog.V(4).InfoS("cannot add policy: not recognized as a telemetry policy", "component", "controller")
 		return
@@ -91,6 +93,18 @@ func (controller *TelemetryPolicyController) onAdd(obj interface{}) {
 	klog.V(2).InfoS("Added policy, "+polCopy.Name, "component", "controller")
 }
 
+//internalPolicy returns a policy watched in the API in the v1alpha1 form, which the cache and the strategies use.
+func internalPolicy(obj interface{}) (*telemetrypolicy.TASPolicy, bool) {
+	switch pol := obj.(type) {
+	case *v1beta1.TASPolicy:
+		return v1beta1.ConvertToV1alpha1(pol), true
+	case *telemetrypolicy.TASPolicy:
+		return pol, true
+	default:
+		return nil, false
+	}
+}
+
 //castStrategy takes in a TASpolicy and returns its specific type based on the structure of the policy file.
 func castStrategy(strategyType string, policy telemetrypolicy.TASPolicyStrategy) (strategy.Interface, error) {
 	switch strategyType {
@@ -113,8 +127,12 @@ func castStrategy(strategyType string, policy telemetrypolicy.TASPolicyStrategy)
 
 //Update deletes the old policy and unregisters strategies and metrics
 func (controller *TelemetryPolicyController) onUpdate(old, new interface{}) {
-	oldPol := old.(*telemetrypolicy.TASPolicy)
-	newPol := new.(*telemetrypolicy.TASPolicy)
+	oldPol, oldOk := internalPolicy(old)
+	newPol, newOk := internalPolicy(new)
+	if !oldOk || !newOk {
+		klog.V(4).InfoS("cannot update policy: not recognized as a telemetry policy", "component", "controller")
+		return
+	}
 	polCopy := newPol.DeepCopy()
 	err := controller.WritePolicy(polCopy.Namespace, polCopy.Name, *polCopy)
 	if err != nil {
@@ -122,6 +140,10 @@ func (controller *TelemetryPolicyController) onUpdate(old, new interface{}) {
 		klog.V(2).InfoS(msg, "component", "controller")
 		return
 	}
+	//status updates leave the strategies alone, as re-adding them would clean up their node labels, metrics and rule states
+	if equality.Semantic.DeepEqual(oldPol.Spec, newPol.Spec) {
+		return
+	}
 	klog.V(2).InfoS("Policy: "+polCopy.Name+" updated", "component", "controller")
 	for name := range polCopy.Spec.Strategies {
 		oldStrat, err := castStrategy(name, oldPol.Spec.Strategies[name])
@@ -155,7 +177,11 @@ func (controller *TelemetryPolicyController) onUpdate(old, new interface{}) {
 
 //On delete gets rid of the policy along with its associated registered strategies and the metrics associated with them.
 func (controller *TelemetryPolicyController) onDelete(obj interface{}) {
-	pol := obj.(*telemetrypolicy.TASPolicy)
+	pol, ok := internalPolicy(obj)
+	if !ok {
+		klog.V(4).InfoS("cannot delete policy: not recognized as a telemetry policy", "component", "controller")
+		return
+	}
 	polCopy := pol.DeepCopy()
 	for name := range polCopy.Spec.Strategies {
 		strt, err := castStrategy(name, polCopy.Spec.Strategies[name])
@@ -5,10 +5,16 @@ package controller
 import (
 	"context"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
 	strategy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/client-go/rest"
 )
@@ -49,3 +55,67 @@ func TestTelemetryPolicyController_Run(t *testing.T) {
 		mockServer.Close()
 	}
 }
+
+//recordingEnforcer records the strategy types added to and removed from it. Removing a strategy cleans up its node
+//labels and rule states, so a strategy which isn't removed keeps them.
+type recordingEnforcer struct {
+	strategy.Enforcer
+	added   []string
+	removed []string
+}
+
+func (e *recordingEnforcer) AddStrategy(_ strategy.Interface, strategyType string) {
+	e.added = append(e.added, strategyType)
+}
+
+func (e *recordingEnforcer) RemoveStrategy(_ strategy.Interface, strategyType string) {
+	e.removed = append(e.removed, strategyType)
+}
+
+func TestTelemetryPolicyController_onUpdate(t *testing.T) {
+	policy := func(target string, lastEnforced time.Time) *v1beta1.TASPolicy {
+		enforced := metav1.NewTime(lastEnforced)
+		return &v1beta1.TASPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Namespace: "default"},
+			Spec: v1beta1.TASPolicySpec{Strategies: v1beta1.TASPolicyStrategies{Deschedule: &v1beta1.TASPolicyStrategy{
+				Rules: []v1beta1.TASPolicyRule{{Metricname: "temperature", Operator: v1beta1.GreaterThan, Target: resource.MustParse(target)}},
+			}}},
+			Status: v1beta1.TASPolicyStatus{Strategies: map[string]v1beta1.TASPolicyStrategyStatus{
+				"deschedule": {LastEnforced: &enforced},
+			}},
+		}
+	}
+	old := policy("80", time.Unix(100, 0))
+	tests := []struct {
+		name        string
+		new         *v1beta1.TASPolicy
+		wantRemoved []string
+		wantMetric  bool
+	}{
+		{"status update leaves the strategies and metrics alone", policy("80", time.Unix(130, 0)), nil, true},
+		{"spec update replaces the strategies", policy("90", time.Unix(100, 0)), []string{"deschedule"}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			metricCache := cache.MockEmptySelfUpdatingCache()
+			enforcer := &recordingEnforcer{}
+			controller := TelemetryPolicyController{Writer: metricCache, Enforcer: enforcer}
+			controller.onAdd(old)
+			err := metricCache.WriteMetric("temperature", metrics.NodeMetricsInfo{"node-1": {Value: resource.MustParse("85")}})
+			if err != nil {
+				t.Fatalf("metric not written: %v", err)
+			}
+			controller.onUpdate(old, tt.new)
+			if !reflect.DeepEqual(enforcer.removed, tt.wantRemoved) {
+				t.Errorf("removed strategies = %v, want %v", enforcer.removed, tt.wantRemoved)
+			}
+			if readded := enforcer.added[1:]; len(readded) != len(tt.wantRemoved) {
+				t.Errorf("re-added strategies = %v, want %v", readded, tt.wantRemoved)
+			}
+			if _, err := metricCache.ReadMetric("temperature"); (err == nil) != tt.wantMetric {
+				t.Errorf("metric present = %v, want %v", err == nil, tt.wantMetric)
+			}
+		})
+	}
+}
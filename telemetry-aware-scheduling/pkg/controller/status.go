@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	strategy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
+	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+//EnforcementReporter tells whether strategies are enforced, and how their last enforcement went.
+type EnforcementReporter interface {
+	IsLeading() bool
+	LastEnforcement(policyName, strategyType string) (strategy.EnforcementResult, bool)
+}
+
+//RunStatusUpdates writes the observed state of every policy to its status subresource once per period, until the
+//context is done. Only the leading replica writes statuses, as the others don't know how the enforcement went.
+func (controller *TelemetryPolicyController) RunStatusUpdates(ctx context.Context, metrics cache.Reader,
+	reporter EnforcementReporter, period time.Duration) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if !reporter.IsLeading() {
+			return
+		}
+		err := controller.updateStatuses(ctx, metrics, reporter)
+		if err != nil {
+			klog.V(2).InfoS("Policy statuses not updated: "+err.Error(), "component", "controller")
+		}
+	}, period)
+}
+
+//updateStatuses lists the policies in all namespaces and writes the status of those whose status has changed.
+func (controller *TelemetryPolicyController) updateStatuses(ctx context.Context, metrics cache.Reader,
+	reporter EnforcementReporter) error {
+	policies := v1beta1.TASPolicyList{}
+	err := controller.Get().Resource(v1beta1.Plural).Do(ctx).Into(&policies)
+	if err != nil {
+		return fmt.Errorf("policy list failed: %w", err)
+	}
+	for i := range policies.Items {
+		pol := &policies.Items[i]
+		internalStatus := policyStatus(v1beta1.ConvertToV1alpha1(pol), metrics, reporter)
+		status := v1beta1.ConvertStatusFromV1alpha1(&internalStatus)
+		if equality.Semantic.DeepEqual(status, pol.Status) {
+			continue
+		}
+		pol.Status = status
+		err := controller.Put().Namespace(pol.Namespace).Resource(v1beta1.Plural).Name(pol.Name).
+			SubResource("status").Body(pol).Do(ctx).Error()
+		if err != nil {
+			klog.V(2).InfoS("Status of policy "+pol.Name+" not updated: "+err.Error(), "component", "controller")
+			continue
+		}
+		klog.V(4).InfoS("Status of policy "+pol.Name+" updated", "component", "controller")
+	}
+	return nil
+}
+
+//policyStatus returns the observed state of each strategy of the policy: the nodes which violate it, how its last
+//enforcement went and whether the metrics of its rules are in the cache. The violating nodes of an enforced strategy
+//are those its last enforcement acted on, as its rules may have state which only the enforcer keeps. Those of a
+//strategy which isn't enforced are the nodes the scheduler extender currently finds violating it.
+func policyStatus(pol *telemetrypolicy.TASPolicy, metrics cache.Reader,
+	reporter EnforcementReporter) telemetrypolicy.TASPolicyStatus {
+	status := telemetrypolicy.TASPolicyStatus{}
+	for name, policyStrategy := range pol.Spec.Strategies {
+		strategyStatus := telemetrypolicy.TASPolicyStrategyStatus{}
+		if result, ok := reporter.LastEnforcement(pol.Name, name); ok {
+			strategyStatus.LastEnforced = statusTime(result.Time)
+			if result.Err != nil {
+				strategyStatus.LastError = result.Err.Error()
+			}
+			strategyStatus.ViolatingNodes = append([]string(nil), result.ViolatingNodes...)
+		} else if strt, err := castStrategy(name, policyStrategy); err == nil {
+			if _, enforceable := strt.(strategy.Enforceable); !enforceable {
+				strt.SetPolicyName(pol.Name)
+				for nodeName := range strt.Violated(metrics) {
+					strategyStatus.ViolatingNodes = append(strategyStatus.ViolatingNodes, nodeName)
+				}
+				sort.Strings(strategyStatus.ViolatingNodes)
+			}
+		}
+		for _, rule := range policyStrategy.Rules {
+			strategyStatus.Rules = append(strategyStatus.Rules, ruleStatus(rule, metrics))
+		}
+		if status.Strategies == nil {
+			status.Strategies = map[string]telemetrypolicy.TASPolicyStrategyStatus{}
+		}
+		status.Strategies[name] = strategyStatus
+	}
+	return status
+}
+
+//ruleStatus tells whether the metric of the rule is in the cache, and the time of its freshest node value.
+func ruleStatus(rule telemetrypolicy.TASPolicyRule, metrics cache.Reader) telemetrypolicy.TASPolicyRuleStatus {
+	status := telemetrypolicy.TASPolicyRuleStatus{Metricname: rule.Metricname}
+	nodeMetrics, err := metrics.ReadMetric(rule.Metricname)
+	if err != nil || len(nodeMetrics) == 0 {
+		return status
+	}
+	status.MetricPresent = true
+	lastUpdated := time.Time{}
+	for _, nodeMetric := range nodeMetrics {
+		if nodeMetric.Timestamp.After(lastUpdated) {
+			lastUpdated = nodeMetric.Timestamp
+		}
+	}
+	if !lastUpdated.IsZero() {
+		status.LastUpdated = statusTime(lastUpdated)
+	}
+	return status
+}
+
+//statusTime returns the time with the precision it has once written to the API, so that an unchanged status
+//compares equal to the one read back.
+func statusTime(t time.Time) *metav1.Time {
+	statusTime := metav1.NewTime(t.Truncate(time.Second))
+	return &statusTime
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	strategy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
+	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//mockReporter reports the given enforcement results, by policy name and strategy type.
+type mockReporter map[string]strategy.EnforcementResult
+
+func (r mockReporter) IsLeading() bool {
+	return true
+}
+
+func (r mockReporter) LastEnforcement(policyName, strategyType string) (strategy.EnforcementResult, bool) {
+	result, ok := r[policyName+"/"+strategyType]
+	return result, ok
+}
+
+func TestPolicyStatus(t *testing.T) {
+	enforced := time.Unix(200, 5)
+	metricTime := metav1.NewTime(time.Unix(100, 0))
+	enforcedTime := metav1.NewTime(time.Unix(200, 0))
+	policy := func(strategyType string, rules ...telemetrypolicy.TASPolicyRule) *telemetrypolicy.TASPolicy {
+		return &telemetrypolicy.TASPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Namespace: "default"},
+			Spec: telemetrypolicy.TASPolicySpec{Strategies: map[string]telemetrypolicy.TASPolicyStrategy{
+				strategyType: {PolicyName: "test-policy", Rules: rules},
+			}},
+		}
+	}
+	tests := []struct {
+		name     string
+		policy   *telemetrypolicy.TASPolicy
+		reporter mockReporter
+		want     telemetrypolicy.TASPolicyStatus
+	}{
+		{"violated strategy which is not enforced",
+			policy("dontschedule", telemetrypolicy.TASPolicyRule{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: resource.MustParse("40")}),
+			mockReporter{},
+			telemetrypolicy.TASPolicyStatus{Strategies: map[string]telemetrypolicy.TASPolicyStrategyStatus{
+				"dontschedule": {ViolatingNodes: []string{"node A"}, Rules: []telemetrypolicy.TASPolicyRuleStatus{
+					{Metricname: "dummyMetric1", MetricPresent: true, LastUpdated: &metricTime},
+				}},
+			}},
+		},
+		{"enforced strategy with a failed enforcement and a missing metric",
+			policy("deschedule", telemetrypolicy.TASPolicyRule{Metricname: "missingMetric", Operator: "GreaterThan", Target: resource.MustParse("40")}),
+			mockReporter{"test-policy/deschedule": {Time: enforced, Err: errors.New("no nodes to label")}},
+			telemetrypolicy.TASPolicyStatus{Strategies: map[string]telemetrypolicy.TASPolicyStrategyStatus{
+				"deschedule": {LastEnforced: &enforcedTime, LastError: "no nodes to label",
+					Rules: []telemetrypolicy.TASPolicyRuleStatus{{Metricname: "missingMetric"}}},
+			}},
+		},
+		{"enforced strategy lists the nodes its enforcement acted on",
+			policy("deschedule", telemetrypolicy.TASPolicyRule{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: resource.MustParse("40")}),
+			mockReporter{"test-policy/deschedule": {Time: enforced, ViolatingNodes: []string{"node B"}}},
+			telemetrypolicy.TASPolicyStatus{Strategies: map[string]telemetrypolicy.TASPolicyStrategyStatus{
+				"deschedule": {LastEnforced: &enforcedTime, ViolatingNodes: []string{"node B"},
+					Rules: []telemetrypolicy.TASPolicyRuleStatus{{Metricname: "dummyMetric1", MetricPresent: true, LastUpdated: &metricTime}}},
+			}},
+		},
+		{"enforced strategy not enforced yet lists no nodes",
+			policy("deschedule", telemetrypolicy.TASPolicyRule{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: resource.MustParse("40")}),
+			mockReporter{},
+			telemetrypolicy.TASPolicyStatus{Strategies: map[string]telemetrypolicy.TASPolicyStrategyStatus{
+				"deschedule": {Rules: []telemetrypolicy.TASPolicyRuleStatus{{Metricname: "dummyMetric1", MetricPresent: true, LastUpdated: &metricTime}}},
+			}},
+		},
+		{"policy without strategies", &telemetrypolicy.TASPolicy{}, mockReporter{}, telemetrypolicy.TASPolicyStatus{}},
+	}
+	metrics := cache.MockSelfUpdatingCache()
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := policyStatus(tt.policy, metrics, tt.reporter)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("policyStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	strategy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
+	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+)
+
+//ValidatePolicy checks each strategy of the policy with the strategy type the controller registers it as, so a
+//policy which passes is one the enforcer and the scheduler extender can act on.
+func ValidatePolicy(pol *telemetrypolicy.TASPolicy) error {
+	names := make([]string, 0, len(pol.Spec.Strategies))
+	for name := range pol.Spec.Strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		strt, err := castStrategy(name, pol.Spec.Strategies[name])
+		if err != nil {
+			return fmt.Errorf("%v: %w", name, err)
+		}
+		if validatable, ok := strt.(strategy.Validatable); ok {
+			if err := validatable.Validate(); err != nil {
+				return fmt.Errorf("%v: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
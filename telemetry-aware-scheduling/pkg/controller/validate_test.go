@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"testing"
+
+	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	rule := func(operator string, labels ...string) telemetrypolicy.TASPolicyRule {
+		return telemetrypolicy.TASPolicyRule{Metricname: "temperature", Operator: operator, Target: resource.MustParse("80"), Labels: labels}
+	}
+	rangeRule := func(operator, lower, upper string) telemetrypolicy.TASPolicyRule {
+		r := rule(operator)
+		if lower != "" {
+			bound := resource.MustParse(lower)
+			r.Lower = &bound
+		}
+		if upper != "" {
+			bound := resource.MustParse(upper)
+			r.Upper = &bound
+		}
+		return r
+	}
+	stateRule := func(operator, clearTarget string, samples int32) telemetrypolicy.TASPolicyRule {
+		r := rule(operator, "hot=true")
+		r.ForSamples = samples
+		if clearTarget != "" {
+			target := resource.MustParse(clearTarget)
+			r.ClearTarget = &target
+		}
+		return r
+	}
+	policy := func(strategyType, logicalOperator string, rules ...telemetrypolicy.TASPolicyRule) *telemetrypolicy.TASPolicy {
+		return &telemetrypolicy.TASPolicy{Spec: telemetrypolicy.TASPolicySpec{
+			Strategies: map[string]telemetrypolicy.TASPolicyStrategy{
+				strategyType: {LogicalOperator: logicalOperator, Rules: rules},
+			},
+		}}
+	}
+	weightedRule := func(weight int32) telemetrypolicy.TASPolicyRule {
+		r := rule("LessThan")
+		r.Weight = weight
+		return r
+	}
+	missingMetricPolicy := func(missingMetric string) *telemetrypolicy.TASPolicy {
+		pol := policy("scheduleonmetric", "", weightedRule(2), rule("GreaterThan"))
+		strategy := pol.Spec.Strategies["scheduleonmetric"]
+		strategy.MissingMetric = missingMetric
+		pol.Spec.Strategies["scheduleonmetric"] = strategy
+		return pol
+	}
+	tests := []struct {
+		name    string
+		policy  *telemetrypolicy.TASPolicy
+		wantErr string
+	}{
+		{"valid deschedule", policy("deschedule", "allOf", rule("GreaterThan"), rule("Equals")), ""},
+		{"valid labeling", policy("labeling", "", rule("LessThan", "hot=true")), ""},
+		{"valid scheduleonmetric", policy("scheduleonmetric", "", rule("LessThan")), ""},
+		{"valid percent change", policy("deschedule", "", rule("PercentChange")), ""},
+		{"valid range", policy("dontschedule", "", rangeRule("InRange", "10", "")), ""},
+		{"valid scheduleonmetric with an inclusive operator", policy("scheduleonmetric", "", rule("GreaterOrEqual")), ""},
+		{"unknown operator", policy("dontschedule", "", rule("Above")),
+			`dontschedule: rule for temperature has an invalid operator "Above"`},
+		{"range without bounds", policy("dontschedule", "", rangeRule("OutOfRange", "", "")),
+			"dontschedule: rule for temperature has no lower or upper bound"},
+		{"valid hysteresis", policy("deschedule", "", stateRule("GreaterThan", "70", 3)), ""},
+		{"clear target beyond the target", policy("labeling", "", stateRule("LessThan", "70", 0)),
+			"labeling: rule for temperature has a clearTarget 70 beyond its target 80"},
+		{"clear target with an unordered operator", policy("deschedule", "", stateRule("Equals", "70", 0)),
+			"deschedule: rule for temperature has a clearTarget, which operator Equals doesn't take"},
+		{"negative samples", policy("deschedule", "", stateRule("GreaterThan", "", -1)),
+			"deschedule: rule for temperature has a negative forSamples"},
+		{"dontschedule with state", policy("dontschedule", "", stateRule("GreaterThan", "", 2)),
+			"dontschedule: rule for temperature has for, forSamples or clearTarget, which only deschedule and labeling take"},
+		{"range with crossed bounds", policy("deschedule", "", rangeRule("InRange", "20", "10")),
+			"deschedule: rule for temperature has a lower bound 20 above its upper bound 10"},
+		{"unknown logical operator", policy("deschedule", "oneOf", rule("GreaterThan")),
+			`deschedule: invalid logicalOperator "oneOf", must be allOf or anyOf`},
+		{"label without a value", policy("labeling", "", rule("GreaterThan", "hot")),
+			`labeling: rule for temperature has an invalid label "hot", labels must be in key=value form`},
+		{"scheduleonmetric without rules", policy("scheduleonmetric", ""), "scheduleonmetric: strategy has no rules"},
+		{"scheduleonmetric with an unordered operator", policy("scheduleonmetric", "", rule("Equals")),
+			`scheduleonmetric: invalid operator "Equals", nodes are ordered by GreaterThan, GreaterOrEqual, LessThan or LessOrEqual`},
+		{"scheduleonmetric with an unordered second rule", policy("scheduleonmetric", "", rule("LessThan"), rule("NotEquals")),
+			`scheduleonmetric: invalid operator "NotEquals", nodes are ordered by GreaterThan, GreaterOrEqual, LessThan or LessOrEqual`},
+		{"scheduleonmetric with a negative weight", policy("scheduleonmetric", "", weightedRule(-1)),
+			"scheduleonmetric: rule for temperature has a negative weight"},
+		{"scheduleonmetric with an unknown missing metric policy", missingMetricPolicy("skip"),
+			`scheduleonmetric: invalid missingMetric "skip", must be penalize, ignore or exclude`},
+		{"valid weighted scheduleonmetric", missingMetricPolicy("ignore"), ""},
+		{"unknown strategy type", policy("scheduleanywhere", "", rule("Equals")),
+			"scheduleanywhere: strategy could not be added - invalid strategy type"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePolicy(tt.policy)
+			if (err == nil && tt.wantErr != "") || (err != nil && err.Error() != tt.wantErr) {
+				t.Errorf("ValidatePolicy() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+//Package instrumentation contains the prometheus metrics TAS exports about itself.
+package instrumentation
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tas"
+
+var (
+	//ViolatingNodes is the number of nodes violating a strategy of a policy in the latest enforcement cycle.
+	ViolatingNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "violating_nodes",
+		Help:      "Number of nodes violating the strategy of a policy in the latest enforcement cycle.",
+	}, []string{"policy", "strategy"})
+
+	//MetricUpdateFailures counts the failed updates of a metric in the cache.
+	MetricUpdateFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_metric_update_failures_total",
+		Help:      "Number of failed updates of a metric in the cache.",
+	}, []string{"metric"})
+
+	//ExtenderRequests counts the scheduler extender requests by verb and outcome.
+	ExtenderRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "extender_requests_total",
+		Help:      "Number of scheduler extender requests by verb and outcome.",
+	}, []string{"verb", "outcome"})
+
+	//ExtenderRequestDuration is the duration of the scheduler extender requests by verb.
+	ExtenderRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "extender_request_duration_seconds",
+		Help:      "Duration of the scheduler extender requests by verb.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"verb"})
+
+	//MetricAgeDesc describes the age of the freshest sample of a metric in the cache.
+	MetricAgeDesc = prometheus.NewDesc(namespace+"_cache_metric_age_seconds",
+		"Age of the freshest node sample of a metric in the cache.", []string{"metric"}, nil)
+)
+
+func init() {
+	prometheus.MustRegister(ViolatingNodes, MetricUpdateFailures, ExtenderRequests, ExtenderRequestDuration)
+}
+
+//Handler returns the handler serving the metrics in the prometheus format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+//ObserveExtenderRequest records the outcome and the duration of a scheduler extender request started at the given time.
+func ObserveExtenderRequest(verb string, start time.Time, failed bool) {
+	outcome := "success"
+	if failed {
+		outcome = "failure"
+	}
+	ExtenderRequests.WithLabelValues(verb, outcome).Inc()
+	ExtenderRequestDuration.WithLabelValues(verb).Observe(time.Since(start).Seconds())
+}
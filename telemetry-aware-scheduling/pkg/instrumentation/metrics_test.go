@@ -0,0 +1,33 @@
+package instrumentation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserveExtenderRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		verb    string
+		failed  bool
+		outcome string
+	}{
+		{"successful filter", "filter", false, "success"},
+		{"failed prioritize", "prioritize", true, "failure"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			before := testutil.ToFloat64(ExtenderRequests.WithLabelValues(tt.verb, tt.outcome))
+			ObserveExtenderRequest(tt.verb, time.Now(), tt.failed)
+			if got := testutil.ToFloat64(ExtenderRequests.WithLabelValues(tt.verb, tt.outcome)); got != before+1 {
+				t.Errorf("requests = %v, want %v", got, before+1)
+			}
+		})
+	}
+	if got := testutil.CollectAndCount(ExtenderRequestDuration); got != 2 {
+		t.Errorf("duration series = %v, want 2", got)
+	}
+}
@@ -22,10 +22,12 @@ type Client interface {
 }
 
 //NodeMetric holds information on a single piece of telemetry data.
+//Previous is the value of the sample before this one, if the cache has seen one.
 type NodeMetric struct {
 	Timestamp time.Time
 	Window    time.Duration
 	Value     resource.Quantity
+	Previous  *resource.Quantity
 }
 
 //NodeMetricsInfo holds a map of metric information related to a single named metric. The key for the map is the name of the node.
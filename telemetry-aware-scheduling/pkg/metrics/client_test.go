@@ -101,7 +101,7 @@ func Test_customMetricsClient_GetNodeMetric(t *testing.T) {
 		want    NodeMetricsInfo
 		wantErr bool
 	}{
-		{"correct metric retrieved", fields{dm}, args{"memoryFree"}, NodeMetricsInfo{"node-1": NodeMetric{baseTimeStamp, time.Duration(1 * time.Minute), *resource.NewQuantity(50, resource.DecimalSI)}}, false},
+		{"correct metric retrieved", fields{dm}, args{"memoryFree"}, NodeMetricsInfo{"node-1": NodeMetric{baseTimeStamp, time.Duration(1 * time.Minute), *resource.NewQuantity(50, resource.DecimalSI), nil}}, false},
 		{"non existent metric query", fields{dm}, args{"nonExistentMetric"}, NodeMetricsInfo{}, true},
 	}
 	for _, tt := range tests {
@@ -3,10 +3,15 @@ package core
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/instrumentation"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
+	telempol "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
@@ -16,6 +21,19 @@ type MetricEnforcer struct {
 	sync.RWMutex
 	RegisteredStrategies map[string]map[Interface]interface{}
 	KubeClient           kubernetes.Interface
+	following            bool
+	enforcements         map[string]EnforcementResult
+	ruleStates           map[string]*ruleState
+	evaluatedNodes       map[string]bool
+	violations           map[string][]string
+}
+
+//EnforcementResult is the outcome of the last enforcement of a strategy. ViolatingNodes are the nodes the enforcement
+//found violating the strategy and acted on, sorted by name.
+type EnforcementResult struct {
+	Time           time.Time
+	Err            error
+	ViolatingNodes []string
 }
 
 //NewEnforcer returns an enforcer with the passed arguments and an empty strategy store.
@@ -23,6 +41,9 @@ func NewEnforcer(kubeClient kubernetes.Interface) *MetricEnforcer {
 	return &MetricEnforcer{
 		RegisteredStrategies: make(map[string]map[Interface]interface{}),
 		KubeClient:           kubeClient,
+		enforcements:         make(map[string]EnforcementResult),
+		ruleStates:           make(map[string]*ruleState),
+		violations:           make(map[string][]string),
 	}
 }
 
@@ -65,13 +86,20 @@ func (e *MetricEnforcer) RegisteredStrategyTypes() []string {
 func (e *MetricEnforcer) RemoveStrategy(str Interface, strategyType string) {
 	e.Lock()
 	defer e.Unlock()
+	e.forgetRuleStates(enforcementKey(str.GetPolicyName(), strategyType), nil)
 	for s := range e.RegisteredStrategies[strategyType] {
 		if s.Equals(str) {
 			delete(e.RegisteredStrategies[strategyType], s)
+			delete(e.enforcements, enforcementKey(s.GetPolicyName(), strategyType))
+			delete(e.violations, enforcementKey(s.GetPolicyName(), strategyType))
+			instrumentation.ViolatingNodes.DeleteLabelValues(s.GetPolicyName(), strategyType)
 			msg := fmt.Sprintf("Removed %v: %v from strategy register", s.GetPolicyName(), strategyType)
 			klog.V(2).InfoS(msg, "component", "controller")
 		}
 	}
+	if e.following {
+		return
+	}
 	if enf, ok := str.(Enforceable); ok {
 		err := enf.Cleanup(e, str.GetPolicyName())
 		if err != nil {
@@ -116,16 +144,98 @@ func (e *MetricEnforcer) EnforceRegisteredStrategies(cache cache.Reader, timer t
 func (e *MetricEnforcer) enforceStrategy(strategyType string, cache cache.Reader) {
 	e.Lock()
 	defer e.Unlock()
+	if e.following {
+		return
+	}
 	strList, ok := e.RegisteredStrategies[strategyType]
 	if ok {
 		for str := range strList {
+			key := enforcementKey(str.GetPolicyName(), strategyType)
+			delete(e.violations, key)
 			if enf, ok := str.(Enforceable); ok {
+				e.evaluatedNodes = map[string]bool{}
 				_, err := enf.Enforce(e, cache)
 				if err != nil {
 					log.Print("Strategy was not enforceable.", err.Error(), "component", "controller")
+				} else {
+					//the states of nodes the enforcement didn't evaluate, e.g. deleted nodes, would otherwise pile up
+					e.forgetRuleStates(key, e.evaluatedNodes)
 				}
+				e.evaluatedNodes = nil
+				if e.enforcements == nil {
+					e.enforcements = make(map[string]EnforcementResult)
+				}
+				e.enforcements[key] = EnforcementResult{Time: time.Now(), Err: err, ViolatingNodes: e.violations[key]}
 			}
+			instrumentation.ViolatingNodes.WithLabelValues(str.GetPolicyName(), strategyType).Set(float64(len(e.violations[key])))
 		}
 	}
 
 }
+
+//LastEnforcement returns the outcome of the last enforcement of the strategy of the given type from the named policy.
+//It returns false if the strategy hasn't been enforced by this enforcer since it was added.
+func (e *MetricEnforcer) LastEnforcement(policyName, strategyType string) (EnforcementResult, bool) {
+	e.RLock()
+	defer e.RUnlock()
+	result, ok := e.enforcements[enforcementKey(policyName, strategyType)]
+	return result, ok
+}
+
+//ReportViolations records the nodes the enforcement of the strategy of the given type from the named policy found
+//violating it, for the violating nodes metric and the result of the enforcement. It is called by strategies while they are enforced, with
+//the enforcer locked, so that the strategy isn't evaluated again apart from its enforcement.
+func (e *MetricEnforcer) ReportViolations(policyName, strategyType string, nodes map[string]interface{}) {
+	if e.violations == nil {
+		e.violations = make(map[string][]string)
+	}
+	nodeNames := make([]string, 0, len(nodes))
+	for nodeName := range nodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	e.violations[enforcementKey(policyName, strategyType)] = nodeNames
+}
+
+func enforcementKey(policyName, strategyType string) string {
+	return policyName + "/" + strategyType
+}
+
+//RuleViolated returns whether the rule at the given index of a strategy is violated on the node with the given metric.
+//Stateful rules are tracked per policy, strategy, rule and node, so that a metric flapping around the target doesn't
+//flip the violation on every enforcement. It is called by strategies while they are enforced, with the enforcer locked.
+func (e *MetricEnforcer) RuleViolated(policyName, strategyType string, ruleIndex int, nodeName string,
+	rule telempol.TASPolicyRule, nodeMetric metrics.NodeMetric) bool {
+	if !Stateful(rule) {
+		return EvaluateMetric(nodeMetric, rule)
+	}
+	if e.ruleStates == nil {
+		e.ruleStates = make(map[string]*ruleState)
+	}
+	key := fmt.Sprintf("%v/%v/%v", enforcementKey(policyName, strategyType), ruleIndex, nodeName)
+	state, ok := e.ruleStates[key]
+	if !ok {
+		state = &ruleState{}
+		e.ruleStates[key] = state
+	}
+	if e.evaluatedNodes != nil {
+		e.evaluatedNodes[nodeName] = true
+	}
+	return state.update(rule, nodeMetric, time.Now())
+}
+
+//forgetRuleStates drops the rule states of the strategy with the given enforcement key, apart from those of the nodes
+//to keep, so that a strategy added again, e.g. after its policy is updated, starts afresh.
+func (e *MetricEnforcer) forgetRuleStates(key string, keepNodes map[string]bool) {
+	for stateKey := range e.ruleStates {
+		if !strings.HasPrefix(stateKey, key+"/") {
+			continue
+		}
+		//state keys are policy/strategy type/rule index/node name
+		if parts := strings.SplitN(stateKey, "/", 4); len(parts) == 4 && keepNodes[parts[3]] {
+			continue
+		}
+		delete(e.ruleStates, stateKey)
+	}
+}
+
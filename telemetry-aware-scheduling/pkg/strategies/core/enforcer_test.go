@@ -1,11 +1,16 @@
 package core
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"k8s.io/klog/v2"
 
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/instrumentation"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
 )
@@ -298,3 +303,126 @@ func TestMetricEnforcer_RemoveStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricEnforcer_enforceStrategyViolatingNodes(t *testing.T) {
+	e := &MetricEnforcer{
+		RegisteredStrategies: map[string]map[Interface]interface{}{"mocko": {mockedStrategy: nil}},
+		KubeClient:           testclient.NewSimpleClientset(),
+	}
+	instrumentation.ViolatingNodes.Reset()
+	e.enforceStrategy("mocko", cache.MockEmptySelfUpdatingCache())
+	if got := testutil.CollectAndCount(instrumentation.ViolatingNodes); got != 1 {
+		t.Errorf("violating nodes series after enforcement = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(instrumentation.ViolatingNodes.WithLabelValues("mock-policy", "mocko")); got != 0 {
+		t.Errorf("violating nodes = %v, want 0", got)
+	}
+	e.RemoveStrategy(mockedStrategy, "mocko")
+	if got := testutil.CollectAndCount(instrumentation.ViolatingNodes); got != 0 {
+		t.Errorf("violating nodes series after removal = %v, want 0", got)
+	}
+}
+
+//reportingStrategy is an enforceable strategy which reports violating nodes when it is enforced, and counts how often
+//it is evaluated apart from its enforcement.
+type reportingStrategy struct {
+	MockStrategy
+	nodes         map[string]interface{}
+	violatedCalls int
+}
+
+func (r *reportingStrategy) Violated(cache cache.Reader) map[string]interface{} {
+	r.violatedCalls++
+	return r.nodes
+}
+
+func (r *reportingStrategy) Enforce(enforcer *MetricEnforcer, cache cache.Reader) (int, error) {
+	enforcer.ReportViolations(r.GetPolicyName(), r.StrategyType(), r.nodes)
+	return len(r.nodes), nil
+}
+
+func (r *reportingStrategy) Cleanup(enforcer *MetricEnforcer, policyName string) error {
+	return nil
+}
+
+func TestMetricEnforcer_enforceStrategyReportedViolations(t *testing.T) {
+	str := &reportingStrategy{MockStrategy: MockStrategy{StrategyTypeMock: "reporting"},
+		nodes: map[string]interface{}{"node-a": nil, "node-b": nil}}
+	e := NewEnforcer(testclient.NewSimpleClientset())
+	e.RegisterStrategyType(str)
+	e.AddStrategy(str, "reporting")
+	instrumentation.ViolatingNodes.Reset()
+	e.enforceStrategy("reporting", cache.MockEmptySelfUpdatingCache())
+	if got := testutil.ToFloat64(instrumentation.ViolatingNodes.WithLabelValues("mock-policy", "reporting")); got != 2 {
+		t.Errorf("violating nodes = %v, want 2", got)
+	}
+	if str.violatedCalls != 0 {
+		t.Errorf("strategy evaluated %v times apart from its enforcement, want 0", str.violatedCalls)
+	}
+	if result, _ := e.LastEnforcement("mock-policy", "reporting"); !reflect.DeepEqual(result.ViolatingNodes, []string{"node-a", "node-b"}) {
+		t.Errorf("enforcement violating nodes = %v, want [node-a node-b]", result.ViolatingNodes)
+	}
+	str.nodes = map[string]interface{}{}
+	e.enforceStrategy("reporting", cache.MockEmptySelfUpdatingCache())
+	if got := testutil.ToFloat64(instrumentation.ViolatingNodes.WithLabelValues("mock-policy", "reporting")); got != 0 {
+		t.Errorf("violating nodes after the violations cleared = %v, want 0", got)
+	}
+	if result, _ := e.LastEnforcement("mock-policy", "reporting"); len(result.ViolatingNodes) != 0 {
+		t.Errorf("enforcement violating nodes after the violations cleared = %v, want none", result.ViolatingNodes)
+	}
+	e.RemoveStrategy(str, "reporting")
+}
+
+//failingStrategy is an enforceable strategy whose enforcement always fails.
+type failingStrategy struct {
+	MockStrategy
+}
+
+func (f *failingStrategy) Enforce(enforcer *MetricEnforcer, cache cache.Reader) (int, error) {
+	return 0, errors.New("enforcement failed")
+}
+
+func (f *failingStrategy) Cleanup(enforcer *MetricEnforcer, policyName string) error {
+	return nil
+}
+
+func TestMetricEnforcer_LastEnforcement(t *testing.T) {
+	str := &failingStrategy{MockStrategy{StrategyTypeMock: "failing"}}
+	tests := []struct {
+		name      string
+		following bool
+		wantOk    bool
+		wantErr   string
+	}{
+		{"leader records the enforcement", false, true, "enforcement failed"},
+		{"follower records nothing", true, false, ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEnforcer(testclient.NewSimpleClientset())
+			e.RegisterStrategyType(str)
+			e.AddStrategy(str, "failing")
+			e.SetLeading(!tt.following)
+			before := time.Now()
+			e.enforceStrategy("failing", cache.MockEmptySelfUpdatingCache())
+			result, ok := e.LastEnforcement("mock-policy", "failing")
+			if ok != tt.wantOk {
+				t.Fatalf("enforcement recorded = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if result.Err == nil || result.Err.Error() != tt.wantErr {
+				t.Errorf("enforcement error = %v, want %v", result.Err, tt.wantErr)
+			}
+			if result.Time.Before(before) {
+				t.Errorf("enforcement time %v is before the enforcement", result.Time)
+			}
+			e.RemoveStrategy(str, "failing")
+			if _, ok := e.LastEnforcement("mock-policy", "failing"); ok {
+				t.Errorf("enforcement still recorded after the strategy was removed")
+			}
+		})
+	}
+}
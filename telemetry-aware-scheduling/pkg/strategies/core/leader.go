@@ -0,0 +1,69 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
+)
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+//StartLeaderElection turns the enforcer into a follower, which keeps its strategy registry up to date but
+//neither enforces strategies nor cleans up after removed ones. The enforcer takes part in the leader election
+//over the named lease in the background, and enforces strategies while it is the leader. This way several
+//replicas can serve the extender endpoints without fighting over node labels.
+func (e *MetricEnforcer) StartLeaderElection(ctx context.Context, namespace, name, identity string) error {
+	e.SetLeading(false)
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Client:     e.KubeClient.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+		},
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		ReleaseOnCancel: true,
+		Name:            name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) { e.SetLeading(true) },
+			OnStoppedLeading: func() { e.SetLeading(false) },
+			OnNewLeader: func(leader string) {
+				klog.V(2).InfoS("TAS leader is "+leader, "component", "controller")
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("leader election setup failed: %w", err)
+	}
+	//a lost leadership ends the run, after which the enforcer is a candidate again
+	go wait.UntilWithContext(ctx, elector.Run, retryPeriod)
+	return nil
+}
+
+//SetLeading switches strategy enforcement on or off.
+func (e *MetricEnforcer) SetLeading(leading bool) {
+	e.Lock()
+	defer e.Unlock()
+	if leading == e.following {
+		klog.V(2).InfoS(fmt.Sprintf("Strategy enforcement leading: %v", leading), "component", "controller")
+	}
+	e.following = !leading
+}
+
+//IsLeading returns true if the enforcer enforces strategies.
+func (e *MetricEnforcer) IsLeading() bool {
+	e.RLock()
+	defer e.RUnlock()
+	return !e.following
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/instrumentation"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMetricEnforcer_SetLeading(t *testing.T) {
+	tests := []struct {
+		name        string
+		leading     bool
+		wantSeries  int
+		wantLeading bool
+	}{
+		{"leading enforcer enforces strategies", true, 1, true},
+		{"following enforcer doesn't enforce strategies", false, 0, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			e := &MetricEnforcer{
+				RegisteredStrategies: map[string]map[Interface]interface{}{"mocko": {mockedStrategy: nil}},
+				KubeClient:           testclient.NewSimpleClientset(),
+			}
+			instrumentation.ViolatingNodes.Reset()
+			e.SetLeading(tt.leading)
+			e.enforceStrategy("mocko", cache.MockEmptySelfUpdatingCache())
+			if got := testutil.CollectAndCount(instrumentation.ViolatingNodes); got != tt.wantSeries {
+				t.Errorf("violating nodes series after enforcement = %v, want %v", got, tt.wantSeries)
+			}
+			if got := e.IsLeading(); got != tt.wantLeading {
+				t.Errorf("IsLeading() = %v, want %v", got, tt.wantLeading)
+			}
+		})
+	}
+}
+
+func TestMetricEnforcer_StartLeaderElection(t *testing.T) {
+	e := NewEnforcer(testclient.NewSimpleClientset())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := e.StartLeaderElection(ctx, "default", "tas", "replica1"); err != nil {
+		t.Fatalf("StartLeaderElection() error = %v", err)
+	}
+	for i := 0; i < 50 && !e.IsLeading(); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !e.IsLeading() {
+		t.Errorf("enforcer wasn't elected as the only candidate")
+	}
+}
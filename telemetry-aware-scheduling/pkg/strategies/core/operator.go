@@ -5,30 +5,112 @@ import (
 
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
 	telempol "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"gopkg.in/inf.v0"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
 )
 
+//PercentChange is the operator of rules which compare the change of a metric since its previous sample, in percent
+//of the previous value, with the target. It needs the previous sample, so it is evaluated by EvaluateMetric.
+const PercentChange = "PercentChange"
+
+//operators holds the functions of the rule operators, by operator name.
+var operators = map[string]func(resource.Quantity, telempol.TASPolicyRule) bool{
+	"LessThan": func(value resource.Quantity, rule telempol.TASPolicyRule) bool {
+		return value.Cmp(rule.Target) == -1
+	},
+	"LessOrEqual": func(value resource.Quantity, rule telempol.TASPolicyRule) bool {
+		return value.Cmp(rule.Target) <= 0
+	},
+	"GreaterThan": func(value resource.Quantity, rule telempol.TASPolicyRule) bool {
+		return value.Cmp(rule.Target) == 1
+	},
+	"GreaterOrEqual": func(value resource.Quantity, rule telempol.TASPolicyRule) bool {
+		return value.Cmp(rule.Target) >= 0
+	},
+	"Equals": func(value resource.Quantity, rule telempol.TASPolicyRule) bool {
+		return value.Cmp(rule.Target) == 0
+	},
+	"NotEquals": func(value resource.Quantity, rule telempol.TASPolicyRule) bool {
+		return value.Cmp(rule.Target) != 0
+	},
+	"InRange": inRange,
+	"OutOfRange": func(value resource.Quantity, rule telempol.TASPolicyRule) bool {
+		return !inRange(value, rule)
+	},
+}
+
+//orderings holds the sort order of the operators which can rank nodes, by operator name. The value tells whether
+//higher metric values come first.
+var orderings = map[string]bool{
+	"GreaterThan":    true,
+	"GreaterOrEqual": true,
+	"LessThan":       false,
+	"LessOrEqual":    false,
+}
+
+//inRange returns true if the value lies between the lower and upper bounds of the rule, bounds included.
+//A bound which isn't set doesn't limit the range.
+func inRange(value resource.Quantity, rule telempol.TASPolicyRule) bool {
+	if rule.Lower != nil && value.Cmp(*rule.Lower) == -1 {
+		return false
+	}
+	if rule.Upper != nil && value.Cmp(*rule.Upper) == 1 {
+		return false
+	}
+	return true
+}
+
 //EvaluateRule returns a boolean after implementing the function described in the TASPolicyRule.
 //The rule is transformed into a function inside of the method.
 func EvaluateRule(value resource.Quantity, rule telempol.TASPolicyRule) bool {
-	operators := map[string]func(resource.Quantity, int64) bool{
-		"LessThan": func(value resource.Quantity, target int64) bool {
-			return value.CmpInt64(target) == -1
-		},
-		"GreaterThan": func(value resource.Quantity, target int64) bool {
-			return value.CmpInt64(target) == 1
-		},
-		"Equals": func(value resource.Quantity, target int64) bool {
-			return value.CmpInt64(target) == 0
-		},
-	}
 	if _, ok := operators[rule.Operator]; !ok {
 		klog.InfoS("Invalid operator type:"+rule.Operator, "component", "controller")
 		return false
 	}
 
-	return operators[rule.Operator](value, rule.Target)
+	return operators[rule.Operator](value, rule)
+}
+
+//EvaluateMetric returns whether the node metric meets the rule. Unlike EvaluateRule it knows PercentChange, which
+//is never met by a metric without a previous sample.
+func EvaluateMetric(nodeMetric metrics.NodeMetric, rule telempol.TASPolicyRule) bool {
+	if rule.Operator != PercentChange {
+		return EvaluateRule(nodeMetric.Value, rule)
+	}
+	if nodeMetric.Previous == nil {
+		return false
+	}
+	//|value - previous| * 100 > target * |previous|, compared as decimals so that no precision is lost in a division.
+	change := nodeMetric.Value.DeepCopy()
+	change.Sub(*nodeMetric.Previous)
+	previous := nodeMetric.Previous.DeepCopy()
+	target := rule.Target.DeepCopy()
+	changeDec := new(inf.Dec).Abs(change.AsDec())
+	changeDec.Mul(changeDec, inf.NewDec(100, 0))
+	limitDec := new(inf.Dec).Abs(previous.AsDec())
+	limitDec.Mul(limitDec, target.AsDec())
+	return changeDec.Cmp(limitDec) == 1
+}
+
+//Orderable returns true if nodes can be ranked by the operator, i.e. scheduleonmetric can use it.
+func Orderable(operator string) bool {
+	_, ok := orderings[operator]
+	return ok
+}
+
+//Precedes returns true if the value ranks before the other under the ordering of the operator: higher values first
+//for GreaterThan and GreaterOrEqual, lower values first for LessThan and LessOrEqual. No value ranks before another
+//under an operator which isn't orderable.
+func Precedes(operator string, value, other resource.Quantity) bool {
+	descending, ok := orderings[operator]
+	if !ok {
+		return false
+	}
+	if descending {
+		return value.Cmp(other) == 1
+	}
+	return value.Cmp(other) == -1
 }
 
 //OrderedList will return a list of nodes ordered by their linked metric and operator
@@ -38,11 +120,8 @@ func OrderedList(metricsInfo metrics.NodeMetricsInfo, operator string) []NodeSor
 	for name, info := range metricsInfo {
 		mtrcs = append(mtrcs, NodeSortableMetric{name, info.Value})
 	}
-	switch operator {
-	case "GreaterThan":
-		sort.Slice(mtrcs, func(i, j int) bool { return mtrcs[i].MetricValue.Cmp(mtrcs[j].MetricValue) == 1 })
-	case "LessThan":
-		sort.Slice(mtrcs, func(i, j int) bool { return mtrcs[i].MetricValue.Cmp(mtrcs[j].MetricValue) == -1 })
+	if Orderable(operator) {
+		sort.Slice(mtrcs, func(i, j int) bool { return Precedes(operator, mtrcs[i].MetricValue, mtrcs[j].MetricValue) })
 	}
 	return mtrcs
 }
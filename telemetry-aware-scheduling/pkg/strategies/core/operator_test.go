@@ -30,14 +30,14 @@ func TestOperator(t *testing.T) {
 		args args
 		want bool
 	}{
-		{name: "LessThan true", args: args{value: 100, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "LessThan", Target: 1000}}, want: true},
-		{name: "GreaterThan true", args: args{value: 100000, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "GreaterThan", Target: 1}}, want: true},
-		{name: "Equals true", args: args{value: 1, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "Equals", Target: 1}}, want: true},
-		{name: "LessThan false", args: args{value: 10000, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "LessThan", Target: 10}}},
-		{name: "GreaterThan false", args: args{value: 1, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "GreaterThan", Target: 10000}}},
-		{name: "Equals false", args: args{value: 1, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "Equals", Target: 100}}},
-		{name: "Invalid Operator", args: args{value: 100, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "ABCDE", Target: 1000}}, want: false},
-		{name: "Blank Operator", args: args{value: 100, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "", Target: 1000}}, want: false},
+		{name: "LessThan true", args: args{value: 100, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "LessThan", Target: resource.MustParse("1000")}}, want: true},
+		{name: "GreaterThan true", args: args{value: 100000, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("1")}}, want: true},
+		{name: "Equals true", args: args{value: 1, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "Equals", Target: resource.MustParse("1")}}, want: true},
+		{name: "LessThan false", args: args{value: 10000, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "LessThan", Target: resource.MustParse("10")}}},
+		{name: "GreaterThan false", args: args{value: 1, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("10000")}}},
+		{name: "Equals false", args: args{value: 1, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "Equals", Target: resource.MustParse("100")}}},
+		{name: "Invalid Operator", args: args{value: 100, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "ABCDE", Target: resource.MustParse("1000")}}, want: false},
+		{name: "Blank Operator", args: args{value: 100, rule: telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: "", Target: resource.MustParse("1000")}}, want: false},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -50,6 +50,107 @@ func TestOperator(t *testing.T) {
 	}
 }
 
+func TestOperatorQuantities(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		operator string
+		target   string
+		want     bool
+	}{
+		{"fraction above fractional target", "0.9", "GreaterThan", "850m", true},
+		{"fraction below fractional target", "0.8", "GreaterThan", "850m", false},
+		{"fraction equal in other form", "0.85", "Equals", "850m", true},
+		{"value just below integer target", "79999m", "LessThan", "80", true},
+		{"binary suffix", "1.6Gi", "GreaterThan", "1.5Gi", true},
+		{"binary suffix against decimal value", "1610612736", "Equals", "1.5Gi", true},
+		{"equal value is greater or equal", "80", "GreaterOrEqual", "80", true},
+		{"lower value is not greater or equal", "79.9", "GreaterOrEqual", "80", false},
+		{"equal value is less or equal", "850m", "LessOrEqual", "0.85", true},
+		{"higher value is not less or equal", "81", "LessOrEqual", "80", false},
+		{"different value is not equal", "81", "NotEquals", "80", true},
+		{"same value in other form is equal", "1k", "NotEquals", "1000", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rule := telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: tt.operator, Target: resource.MustParse(tt.target)}
+			if got := EvaluateRule(resource.MustParse(tt.value), rule); got != tt.want {
+				t.Errorf("EvaluateRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperatorRanges(t *testing.T) {
+	bound := func(value string) *resource.Quantity {
+		if value == "" {
+			return nil
+		}
+		quantity := resource.MustParse(value)
+		return &quantity
+	}
+	tests := []struct {
+		name     string
+		value    string
+		operator string
+		lower    string
+		upper    string
+		want     bool
+	}{
+		{"value inside the range", "50", "InRange", "10", "90", true},
+		{"value on the lower bound", "10", "InRange", "10", "90", true},
+		{"value on the upper bound", "90", "InRange", "10", "90", true},
+		{"value below the range", "9", "InRange", "10", "90", false},
+		{"value above an open range", "1M", "InRange", "10", "", true},
+		{"value below an open range", "-1", "InRange", "", "90", true},
+		{"value outside the range", "95", "OutOfRange", "10", "90", true},
+		{"value on a bound is not out of range", "90", "OutOfRange", "10", "90", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rule := telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: tt.operator, Lower: bound(tt.lower), Upper: bound(tt.upper)}
+			if got := EvaluateRule(resource.MustParse(tt.value), rule); got != tt.want {
+				t.Errorf("EvaluateRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateMetric(t *testing.T) {
+	previous := func(value string) *resource.Quantity {
+		quantity := resource.MustParse(value)
+		return &quantity
+	}
+	tests := []struct {
+		name     string
+		metric   metrics.NodeMetric
+		operator string
+		target   string
+		want     bool
+	}{
+		{"rise above the target percentage", metrics.NodeMetric{Value: resource.MustParse("120"), Previous: previous("100")}, "PercentChange", "10", true},
+		{"fall above the target percentage", metrics.NodeMetric{Value: resource.MustParse("80"), Previous: previous("100")}, "PercentChange", "10", true},
+		{"change equal to the target percentage", metrics.NodeMetric{Value: resource.MustParse("110"), Previous: previous("100")}, "PercentChange", "10", false},
+		{"fractional change", metrics.NodeMetric{Value: resource.MustParse("1.01"), Previous: previous("1")}, "PercentChange", "500m", true},
+		{"negative previous value", metrics.NodeMetric{Value: resource.MustParse("-5"), Previous: previous("-10")}, "PercentChange", "40", true},
+		{"change from zero", metrics.NodeMetric{Value: resource.MustParse("1m"), Previous: previous("0")}, "PercentChange", "1000", true},
+		{"no change from zero", metrics.NodeMetric{Value: resource.MustParse("0"), Previous: previous("0")}, "PercentChange", "0", false},
+		{"no previous sample", metrics.NodeMetric{Value: resource.MustParse("120")}, "PercentChange", "10", false},
+		{"other operators compare the value", metrics.NodeMetric{Value: resource.MustParse("120"), Previous: previous("100")}, "GreaterThan", "110", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rule := telemetrypolicy.TASPolicyRule{Metricname: "memory", Operator: tt.operator, Target: resource.MustParse(tt.target)}
+			if got := EvaluateMetric(tt.metric, rule); got != tt.want {
+				t.Errorf("EvaluateMetric() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestOrderedList(t *testing.T) {
 	type args struct {
 		metricsInfo metrics.NodeMetricsInfo
@@ -62,6 +163,8 @@ func TestOrderedList(t *testing.T) {
 	}{
 		{"less than test", args{testNodeMetricCustomInfo([]string{"node A", "node B", "node C"}, []int64{100, 200, 10}), "LessThan"}, []NodeSortableMetric{{"node C", *resource.NewQuantity(10, resource.DecimalSI)}, {"node A", *resource.NewQuantity(100, resource.DecimalSI)}, {"node B", *resource.NewQuantity(200, resource.DecimalSI)}}},
 		{"greater than test", args{testNodeMetricCustomInfo([]string{"node A", "node B", "node C"}, []int64{100, 200, 10}), "GreaterThan"}, []NodeSortableMetric{{"node B", *resource.NewQuantity(200, resource.DecimalSI)}, {"node A", *resource.NewQuantity(100, resource.DecimalSI)}, {"node C", *resource.NewQuantity(10, resource.DecimalSI)}}},
+		{"less or equal test", args{testNodeMetricCustomInfo([]string{"node A", "node B", "node C"}, []int64{100, 200, 10}), "LessOrEqual"}, []NodeSortableMetric{{"node C", *resource.NewQuantity(10, resource.DecimalSI)}, {"node A", *resource.NewQuantity(100, resource.DecimalSI)}, {"node B", *resource.NewQuantity(200, resource.DecimalSI)}}},
+		{"greater or equal test", args{testNodeMetricCustomInfo([]string{"node A", "node B", "node C"}, []int64{100, 200, 10}), "GreaterOrEqual"}, []NodeSortableMetric{{"node B", *resource.NewQuantity(200, resource.DecimalSI)}, {"node A", *resource.NewQuantity(100, resource.DecimalSI)}, {"node C", *resource.NewQuantity(10, resource.DecimalSI)}}},
 	}
 	for _, tt := range tests {
 		tt := tt
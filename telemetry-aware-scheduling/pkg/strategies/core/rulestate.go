@@ -0,0 +1,52 @@
+package core
+
+import (
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
+	telempol "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+)
+
+//ruleState is what the enforcer remembers of a rule on a node between enforcements.
+type ruleState struct {
+	violated   bool
+	since      time.Time
+	samples    int32
+	lastSample time.Time
+}
+
+//Stateful returns true if whether the rule is violated depends on earlier values of its metric, i.e. it has a for
+//duration, a number of samples or a clear target.
+func Stateful(rule telempol.TASPolicyRule) bool {
+	return rule.For != nil || rule.ForSamples > 0 || rule.ClearTarget != nil
+}
+
+//update takes in the current metric of the node and returns whether the rule is violated on it. A node which isn't
+//violating the rule starts to once it has broken the rule for the for duration and for the number of consecutive
+//samples of the rule. It stops once it no longer breaks the rule evaluated against the clear target, if there is one.
+func (s *ruleState) update(rule telempol.TASPolicyRule, nodeMetric metrics.NodeMetric, now time.Time) bool {
+	if s.violated {
+		clearRule := rule
+		if rule.ClearTarget != nil {
+			clearRule.Target = *rule.ClearTarget
+		}
+		if EvaluateMetric(nodeMetric, clearRule) {
+			return true
+		}
+		*s = ruleState{}
+		return false
+	}
+	if !EvaluateMetric(nodeMetric, rule) {
+		*s = ruleState{}
+		return false
+	}
+	if s.samples == 0 {
+		s.since = now
+	}
+	if s.samples == 0 || !nodeMetric.Timestamp.Equal(s.lastSample) {
+		s.samples++
+		s.lastSample = nodeMetric.Timestamp
+	}
+	s.violated = s.samples >= rule.ForSamples && (rule.For == nil || now.Sub(s.since) >= rule.For.Duration)
+	return s.violated
+}
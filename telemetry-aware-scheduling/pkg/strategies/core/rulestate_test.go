@@ -0,0 +1,119 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
+	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRuleState_update(t *testing.T) {
+	type sample struct {
+		value   string
+		second  int64
+		elapsed time.Duration
+		want    bool
+	}
+	clearTarget := resource.MustParse("70")
+	tests := []struct {
+		name    string
+		rule    telemetrypolicy.TASPolicyRule
+		samples []sample
+	}{
+		{"rule without state follows the value",
+			telemetrypolicy.TASPolicyRule{Operator: "GreaterThan", Target: resource.MustParse("80")},
+			[]sample{{"90", 1, 0, true}, {"75", 2, 0, false}, {"85", 3, 0, true}}},
+		{"consecutive samples",
+			telemetrypolicy.TASPolicyRule{Operator: "GreaterThan", Target: resource.MustParse("80"), ForSamples: 3},
+			[]sample{{"90", 1, 0, false}, {"90", 1, 0, false}, {"90", 2, 0, false}, {"90", 3, 0, true}, {"75", 4, 0, false},
+				{"90", 5, 0, false}}},
+		{"for duration",
+			telemetrypolicy.TASPolicyRule{Operator: "GreaterThan", Target: resource.MustParse("80"), For: &metav1.Duration{Duration: time.Minute}},
+			[]sample{{"90", 1, 0, false}, {"90", 2, 30 * time.Second, false}, {"90", 3, 60 * time.Second, true},
+				{"75", 4, 90 * time.Second, false}, {"90", 5, 120 * time.Second, false}}},
+		{"clear target",
+			telemetrypolicy.TASPolicyRule{Operator: "GreaterThan", Target: resource.MustParse("80"), ClearTarget: &clearTarget},
+			[]sample{{"75", 1, 0, false}, {"85", 2, 0, true}, {"75", 3, 0, true}, {"71", 4, 0, true}, {"70", 5, 0, false},
+				{"75", 6, 0, false}}},
+	}
+	start := time.Unix(1000, 0)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			state := &ruleState{}
+			for i, s := range tt.samples {
+				nodeMetric := metrics.NodeMetric{Value: resource.MustParse(s.value), Timestamp: time.Unix(s.second, 0)}
+				if got := state.update(tt.rule, nodeMetric, start.Add(s.elapsed)); got != s.want {
+					t.Errorf("sample %v: update() = %v, want %v", i, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMetricEnforcer_RuleViolated(t *testing.T) {
+	e := NewEnforcer(testclient.NewSimpleClientset())
+	rule := telemetrypolicy.TASPolicyRule{Operator: "GreaterThan", Target: resource.MustParse("80"), ForSamples: 2}
+	violated := func(nodeName string, second int64) bool {
+		nodeMetric := metrics.NodeMetric{Value: resource.MustParse("90"), Timestamp: time.Unix(second, 0)}
+		return e.RuleViolated("mock-policy", "mocko", 0, nodeName, rule, nodeMetric)
+	}
+	if violated("node A", 1) || !violated("node A", 2) {
+		t.Errorf("rule not violated after its second sample")
+	}
+	if violated("node B", 2) {
+		t.Errorf("rule state shared between nodes")
+	}
+	e.RemoveStrategy(mockedStrategy, "mocko")
+	if len(e.ruleStates) != 0 {
+		t.Errorf("rule states %v kept after the strategy was removed", e.ruleStates)
+	}
+}
+
+//statefulStrategy is an enforceable strategy whose enforcement evaluates a stateful rule on the given nodes.
+type statefulStrategy struct {
+	MockStrategy
+	nodes []string
+	err   error
+}
+
+func (s *statefulStrategy) Enforce(enforcer *MetricEnforcer, cache cache.Reader) (int, error) {
+	rule := telemetrypolicy.TASPolicyRule{Operator: "GreaterThan", Target: resource.MustParse("80"), ForSamples: 2}
+	for _, nodeName := range s.nodes {
+		enforcer.RuleViolated(s.GetPolicyName(), s.StrategyType(), 0, nodeName, rule,
+			metrics.NodeMetric{Value: resource.MustParse("90"), Timestamp: time.Now()})
+	}
+	return 0, s.err
+}
+
+func (s *statefulStrategy) Cleanup(enforcer *MetricEnforcer, policyName string) error {
+	return nil
+}
+
+func TestMetricEnforcer_enforceStrategyRuleStates(t *testing.T) {
+	str := &statefulStrategy{MockStrategy: MockStrategy{StrategyTypeMock: "stateful"}, nodes: []string{"node A", "node B"}}
+	e := NewEnforcer(testclient.NewSimpleClientset())
+	e.RegisterStrategyType(str)
+	e.AddStrategy(str, "stateful")
+	e.enforceStrategy("stateful", cache.MockEmptySelfUpdatingCache())
+	if len(e.ruleStates) != 2 {
+		t.Errorf("rule states = %v, want 2", len(e.ruleStates))
+	}
+	str.nodes, str.err = []string{}, errors.New("metric not found")
+	e.enforceStrategy("stateful", cache.MockEmptySelfUpdatingCache())
+	if len(e.ruleStates) != 2 {
+		t.Errorf("rule states after a failed enforcement = %v, want 2", len(e.ruleStates))
+	}
+	str.nodes, str.err = []string{"node A"}, nil
+	e.enforceStrategy("stateful", cache.MockEmptySelfUpdatingCache())
+	if _, ok := e.ruleStates["mock-policy/stateful/0/node A"]; !ok || len(e.ruleStates) != 1 {
+		t.Errorf("rule states %v, want only that of node A", e.ruleStates)
+	}
+	e.RemoveStrategy(str, "stateful")
+}
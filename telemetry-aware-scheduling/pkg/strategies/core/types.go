@@ -23,6 +23,12 @@ type Enforceable interface {
 	Cleanup(enforcer *MetricEnforcer, policyName string) error
 }
 
+//Validatable strategies check their rules before they are accepted, so that a malformed policy is rejected rather
+//than silently ignored during enforcement.
+type Validatable interface {
+	Validate() error
+}
+
 //Enforcer registers strategies by type, adds specific strategies to a registry, and Enforces those strategies.
 type Enforcer interface {
 	RegisterStrategyType(strategy Interface)
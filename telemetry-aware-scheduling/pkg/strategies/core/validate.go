@@ -0,0 +1,88 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	telempol "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+)
+
+//Errors returned by the validation of strategies.
+var (
+	ErrNoRules = errors.New("strategy has no rules")
+)
+
+//ValidateRule checks that the rule names a metric and has an operator EvaluateMetric knows. The range operators
+//need at least one bound, and a lower bound which isn't above the upper one.
+//A clear target is only taken by the operators which order values, and mustn't be on the breaking side of the target.
+func ValidateRule(rule telempol.TASPolicyRule) error {
+	if rule.Metricname == "" {
+		return errors.New("rule has no metricname")
+	}
+	if _, ok := operators[rule.Operator]; !ok && rule.Operator != PercentChange {
+		return fmt.Errorf("rule for %v has an invalid operator %q", rule.Metricname, rule.Operator)
+	}
+	if err := validateRuleState(rule); err != nil {
+		return err
+	}
+	if rule.Operator != "InRange" && rule.Operator != "OutOfRange" {
+		return nil
+	}
+	if rule.Lower == nil && rule.Upper == nil {
+		return fmt.Errorf("rule for %v has no lower or upper bound", rule.Metricname)
+	}
+	if rule.Lower != nil && rule.Upper != nil && rule.Lower.Cmp(*rule.Upper) == 1 {
+		return fmt.Errorf("rule for %v has a lower bound %v above its upper bound %v", rule.Metricname,
+			rule.Lower.String(), rule.Upper.String())
+	}
+	return nil
+}
+
+//ValidateRules checks the logical operator and each of the rules of a strategy. The logical operator is either
+//"allOf" or "anyOf", or it is left out, which means "anyOf".
+func ValidateRules(logicalOperator string, rules []telempol.TASPolicyRule) error {
+	switch logicalOperator {
+	case "", "allOf", "anyOf":
+	default:
+		return fmt.Errorf("invalid logicalOperator %q, must be allOf or anyOf", logicalOperator)
+	}
+	for _, rule := range rules {
+		if err := ValidateRule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//validateRuleState checks the for duration, number of samples and clear target of the rule.
+func validateRuleState(rule telempol.TASPolicyRule) error {
+	if rule.For != nil && rule.For.Duration < 0 {
+		return fmt.Errorf("rule for %v has a negative for duration", rule.Metricname)
+	}
+	if rule.ForSamples < 0 {
+		return fmt.Errorf("rule for %v has a negative forSamples", rule.Metricname)
+	}
+	if rule.ClearTarget == nil {
+		return nil
+	}
+	if !Orderable(rule.Operator) {
+		return fmt.Errorf("rule for %v has a clearTarget, which operator %v doesn't take", rule.Metricname, rule.Operator)
+	}
+	if Precedes(rule.Operator, *rule.ClearTarget, rule.Target) {
+		return fmt.Errorf("rule for %v has a clearTarget %v beyond its target %v", rule.Metricname,
+			rule.ClearTarget.String(), rule.Target.String())
+	}
+	return nil
+}
+
+//ValidateStateless checks that none of the rules has a for duration, a number of samples or a clear target, for
+//strategies which don't keep rule state.
+func ValidateStateless(rules []telempol.TASPolicyRule) error {
+	for _, rule := range rules {
+		if Stateful(rule) {
+			return fmt.Errorf("rule for %v has for, forSamples or clearTarget, which only deschedule and labeling take",
+				rule.Metricname)
+		}
+	}
+	return nil
+}
@@ -155,7 +155,13 @@ func (d *Strategy) nodeStatusForStrategy(enforcer *strategy.MetricEnforcer, cach
 	violations := violationList{}
 	for strat := range enforcer.RegisteredStrategies[StrategyType] {
 		klog.V(2).InfoS("Evaluating "+strat.GetPolicyName(), "component", "controller")
-		nodes := strat.Violated(cache)
+		var nodes map[string]interface{}
+		if descheduleStrategy, ok := strat.(*Strategy); ok {
+			nodes = descheduleStrategy.enforcedViolations(enforcer, cache)
+		} else {
+			nodes = strat.Violated(cache)
+		}
+		enforcer.ReportViolations(strat.GetPolicyName(), StrategyType, nodes)
 		for node := range nodes {
 			violations[node] = append(violations[node], strat.GetPolicyName())
 		}
@@ -36,13 +36,13 @@ func TestDescheduleStrategy_Enforce(t *testing.T) {
 		want    expected
 	}{
 		{name: "node label test",
-			d:    &Strategy{PolicyName: "deschedule-test", Rules: []telpol.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 1}, {Metricname: "cpu", Operator: "LessThan", Target: 10}}},
+			d:    &Strategy{PolicyName: "deschedule-test", Rules: []telpol.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("1")}, {Metricname: "cpu", Operator: "LessThan", Target: resource.MustParse("10")}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"deschedule-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()),
 				cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}}},
 		{name: "node unlabel test",
-			d:    &Strategy{PolicyName: "deschedule-test", Rules: []telpol.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 1000}, {Metricname: "cpu", Operator: "LessThan", Target: 10}}},
+			d:    &Strategy{PolicyName: "deschedule-test", Rules: []telpol.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("1000")}, {Metricname: "cpu", Operator: "LessThan", Target: resource.MustParse("10")}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"deschedule-test": "violating"}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()),
 				cache: cache.MockEmptySelfUpdatingCache()},
@@ -86,3 +86,34 @@ func TestDescheduleStrategy_Enforce(t *testing.T) {
 		})
 	}
 }
+
+func TestDescheduleStrategy_EnforceForSamples(t *testing.T) {
+	enforcer := strategy.NewEnforcer(testclient.NewSimpleClientset())
+	metricCache := cache.MockEmptySelfUpdatingCache()
+	d := &Strategy{PolicyName: "deschedule-test", Rules: []telpol.TASPolicyRule{
+		{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("1"), ForSamples: 2}}}
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"deschedule-test": ""}}}
+	if _, err := enforcer.KubeClient.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Cannot create node for test: %v", err)
+	}
+	enforcer.RegisterStrategyType(d)
+	enforcer.AddStrategy(d, d.StrategyType())
+	for i, wantLabelled := range []bool{false, true} {
+		err := metricCache.WriteMetric("memory", metrics.NodeMetricsInfo{"node-1": {Timestamp: time.Unix(int64(i), 0),
+			Window: 1, Value: *resource.NewQuantity(100, resource.DecimalSI)}})
+		if err != nil {
+			t.Fatalf("Cannot write metric to mock cache for test: %v", err)
+		}
+		if _, err := d.Enforce(enforcer, metricCache); err != nil {
+			t.Fatalf("Strategy.Enforce() error = %v", err)
+		}
+		labelledNodes, err := enforcer.KubeClient.CoreV1().Nodes().List(context.TODO(),
+			metav1.ListOptions{LabelSelector: "deschedule-test=violating"})
+		if err != nil {
+			t.Fatalf("Cannot list nodes: %v", err)
+		}
+		if got := len(labelledNodes.Items) == 1; got != wantLabelled {
+			t.Errorf("sample %v: node labelled = %v, want %v", i, got, wantLabelled)
+		}
+	}
+}
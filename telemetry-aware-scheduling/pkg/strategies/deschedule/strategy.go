@@ -9,6 +9,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
 	telempol "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
 )
@@ -26,13 +27,32 @@ func (d *Strategy) StrategyType() string {
 	return StrategyType
 }
 
+//ruleEvaluation tells whether the rule at the given index is violated on the node with the given metric.
+type ruleEvaluation func(ruleIndex int, nodeName string, rule telempol.TASPolicyRule, nodeMetric metrics.NodeMetric) bool
+
 //Violated checks to see if the strategy is violated by searching for nodes that have metrics that don't accord with the target in descheduling strategy.
 //Returns a map of nodeNames as key with an empty value associated with each.
 func (d *Strategy) Violated(cache cache.Reader) map[string]interface{} {
+	return d.violated(cache, func(_ int, _ string, rule telempol.TASPolicyRule, nodeMetric metrics.NodeMetric) bool {
+		return core.EvaluateMetric(nodeMetric, rule)
+	})
+}
+
+//enforcedViolations is like Violated, but rules are evaluated against the rule state kept by the enforcer, so that
+//their for durations, samples and clear targets are honored.
+func (d *Strategy) enforcedViolations(enforcer *core.MetricEnforcer, cache cache.Reader) map[string]interface{} {
+	return d.violated(cache, func(ruleIndex int, nodeName string, rule telempol.TASPolicyRule,
+		nodeMetric metrics.NodeMetric) bool {
+		return enforcer.RuleViolated(d.PolicyName, StrategyType, ruleIndex, nodeName, rule, nodeMetric)
+	})
+}
+
+//violated returns the nodes violating the strategy, with its rules evaluated by the given function.
+func (d *Strategy) violated(cache cache.Reader, evaluate ruleEvaluation) map[string]interface{} {
 	violatingNodes := map[string]interface{}{}
 	nodeMetricViol := map[string]int{}
 
-	for _, rule := range d.Rules {
+	for i, rule := range d.Rules {
 		nodeMetrics, err := cache.ReadMetric(rule.Metricname)
 
 		if err != nil {
@@ -45,7 +65,7 @@ func (d *Strategy) Violated(cache cache.Reader) map[string]interface{} {
 			msg := fmt.Sprint(nodeName+" "+rule.Metricname, " = ", nodeMetric.Value.AsDec())
 			klog.V(4).InfoS(msg, "component", "controller")
 
-			if core.EvaluateRule(nodeMetric.Value, rule) {
+			if evaluate(i, nodeName, rule, nodeMetric) {
 				klog.V(2).Infof("%v violated in node %v", rule.Metricname, nodeName)
 				nodeMetricViol[nodeName]++
 
@@ -71,7 +91,7 @@ func (d *Strategy) Violated(cache cache.Reader) map[string]interface{} {
 
 //ruleToString returns the rule passed to it as a single string
 func ruleToString(rule telempol.TASPolicyRule) string {
-	return fmt.Sprintf("%v %v %v", rule.Metricname, rule.Operator, rule.Target)
+	return fmt.Sprintf("%v %v %v", rule.Metricname, rule.Operator, rule.Target.String())
 }
 
 //Equals checks if a strategy is the same as the passed strategy.
@@ -85,7 +105,7 @@ func (d *Strategy) Equals(other core.Interface) bool {
 			if rule.Metricname != otherDeschedulerStrategy.Rules[i].Metricname {
 				return false
 			}
-			if rule.Target != otherDeschedulerStrategy.Rules[i].Target {
+			if rule.Target.Cmp(otherDeschedulerStrategy.Rules[i].Target) != 0 {
 				return false
 			}
 			if rule.Operator != otherDeschedulerStrategy.Rules[i].Operator {
@@ -106,3 +126,8 @@ func (d *Strategy) GetPolicyName() string {
 func (d *Strategy) SetPolicyName(name string) {
 	d.PolicyName = name
 }
+
+// Validate checks the logical operator and the rules of the strategy.
+func (d *Strategy) Validate() error {
+	return core.ValidateRules(d.LogicalOperator, d.Rules)
+}
@@ -69,13 +69,13 @@ func TestDescheduleStrategy_Equals(t *testing.T) {
 		args args
 		want bool
 	}{
-		{name: "Equal empty strategies", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}},
-		{name: "Equal one rule per strategy", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}, want: true},
-		{name: "different number rules same order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: 1}, {Metricname: "memory", Operator: "GreaterThan", Target: 50}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}},
-		{name: "Not equal different number rules different order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}, {Metricname: "cpu", Operator: "Equals", Target: 1}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}},
-		{name: "Not equal different rule names", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "GreaterThan", Target: 50}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}},
-		{name: "Not equal different operator", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "LessThan", Target: 50}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}},
-		{name: "Not equal different target", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 10}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}},
+		{name: "Equal empty strategies", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}},
+		{name: "Equal one rule per strategy", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}, want: true},
+		{name: "different number rules same order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("1")}, {Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}},
+		{name: "Not equal different number rules different order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}, {Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("1")}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}},
+		{name: "Not equal different rule names", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "GreaterThan", Target: resource.MustParse("50")}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}},
+		{name: "Not equal different operator", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "LessThan", Target: resource.MustParse("50")}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}},
+		{name: "Not equal different target", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("10")}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -327,7 +327,7 @@ func metricRules(metricname string, operator string, target int64) v1.TASPolicyR
 	return v1.TASPolicyRule{
 		Metricname: metricname,
 		Operator:   operator,
-		Target:     target,
+		Target:     *resource.NewQuantity(target, resource.DecimalSI),
 	}
 }
 func TestDescheduleStrategy_StrategyType(t *testing.T) {
@@ -38,7 +38,7 @@ func (d *Strategy) Violated(cache cache.Reader) map[string]interface{} {
 			msg := fmt.Sprint(nodeName+" "+rule.Metricname, " = ", nodeMetric.Value.AsDec())
 			klog.V(2).InfoS(msg, "component", "controller")
 
-			if core.EvaluateRule(nodeMetric.Value, rule) {
+			if core.EvaluateMetric(nodeMetric, rule) {
 				nodeMetricViol[nodeName]++
 
 				if d.LogicalOperator == "allOf" {
@@ -81,7 +81,7 @@ func (d *Strategy) Equals(other core.Interface) bool {
 			if rule.Metricname != OtherDontScheduleStrategy.Rules[i].Metricname {
 				return false
 			}
-			if rule.Target != OtherDontScheduleStrategy.Rules[i].Target {
+			if rule.Target.Cmp(OtherDontScheduleStrategy.Rules[i].Target) != 0 {
 				return false
 			}
 			if rule.Operator != OtherDontScheduleStrategy.Rules[i].Operator {
@@ -106,5 +106,14 @@ func (d *Strategy) GetPolicyName() string {
 
 //Formats the rules as an interpretable string.
 func ruleToString(rule telemetryPolicyV1.TASPolicyRule) string {
-	return fmt.Sprintf("%v %v %v", rule.Metricname, rule.Operator, rule.Target)
+	return fmt.Sprintf("%v %v %v", rule.Metricname, rule.Operator, rule.Target.String())
+}
+
+//Validate checks the logical operator and the rules of the strategy. Rules can't have state, as they are checked
+//by the scheduler extender rather than the enforcer.
+func (d *Strategy) Validate() error {
+	if err := core.ValidateRules(d.LogicalOperator, d.Rules); err != nil {
+		return err
+	}
+	return core.ValidateStateless(d.Rules)
 }
@@ -217,7 +217,7 @@ func metricRules(metricname string, operator string, target int64) v1.TASPolicyR
 	return v1.TASPolicyRule{
 		Metricname: metricname,
 		Operator:   operator,
-		Target:     target,
+		Target:     *resource.NewQuantity(target, resource.DecimalSI),
 	}
 }
 
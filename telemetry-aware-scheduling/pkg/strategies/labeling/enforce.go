@@ -205,8 +205,7 @@ func minMaxFilterViolatedRules(violationResult interface{}) map[string]ruleResul
 				log.Panic()
 			}
 
-			if !old || old && ((result.rule.Operator == "GreaterThan" && result.quantity.Cmp(olderRes.quantity) > 0) ||
-				(result.rule.Operator == "LessThan" && result.quantity.Cmp(olderRes.quantity) < 0)) {
+			if !old || strategy.Precedes(result.rule.Operator, result.quantity, olderRes.quantity) {
 				violatedRules[name] = result
 			}
 		}
@@ -240,7 +239,13 @@ func (d *Strategy) nodeStatusForStrategy(enforcer *strategy.MetricEnforcer,
 		policyName := strategy.GetPolicyName()
 		klog.V(2).InfoS("Evaluating "+policyName, "component", "controller")
 
-		nodes := strategy.Violated(cache)
+		var nodes map[string]interface{}
+		if labelingStrategy, ok := strategy.(*Strategy); ok {
+			nodes = labelingStrategy.enforcedViolations(enforcer, cache)
+		} else {
+			nodes = strategy.Violated(cache)
+		}
+		enforcer.ReportViolations(policyName, StrategyType, nodes)
 
 		for nodeName, violationResult := range nodes {
 			if _, ok := violations[nodeName]; !ok {
@@ -39,7 +39,7 @@ func TestLabelingStrategy_Enforce(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 99, Labels: []string{"gpu-card1=false"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("99"), Labels: []string{"gpu-card1=false"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{"telemetry.aware.scheduling.labeling-test/gpu-card1": "false"}}},
@@ -48,7 +48,7 @@ func TestLabelingStrategy_Enforce(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 3000, Labels: []string{"gpu-card0=false"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("3000"), Labels: []string{"gpu-card0=false"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -58,8 +58,8 @@ func TestLabelingStrategy_Enforce(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 99, Labels: []string{"gpu-card1=false"}},
-					{Metricname: "cpu", Operator: "GreaterThan", Target: 10, Labels: []string{"gpu-card2=true"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("99"), Labels: []string{"gpu-card1=false"}},
+					{Metricname: "cpu", Operator: "GreaterThan", Target: resource.MustParse("10"), Labels: []string{"gpu-card2=true"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{"telemetry.aware.scheduling.labeling-test/gpu-card1": "false",
@@ -71,8 +71,8 @@ func TestLabelingStrategy_Enforce(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 100, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "GreaterThan", Target: 100, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("100"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "GreaterThan", Target: resource.MustParse("100"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{"telemetry.aware.scheduling.labeling-test/gpu-device": "card0"}}},
@@ -83,8 +83,8 @@ func TestLabelingStrategy_Enforce(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "LessThan", Target: 10000, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "LessThan", Target: 10000, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "LessThan", Target: resource.MustParse("10000"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "LessThan", Target: resource.MustParse("10000"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{"telemetry.aware.scheduling.labeling-test/gpu-device": "card1"}}},
@@ -93,8 +93,8 @@ func TestLabelingStrategy_Enforce(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "Equals", Target: 2000, Labels: []string{"gpu-device=card1"}},
-					{Metricname: "cpu", Operator: "Equals", Target: 200, Labels: []string{"gpu-device=card0"}}}},
+					{Metricname: "memory", Operator: "Equals", Target: resource.MustParse("2000"), Labels: []string{"gpu-device=card1"}},
+					{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card0"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{"telemetry.aware.scheduling.labeling-test/gpu-device": "card1"}}},
@@ -103,8 +103,8 @@ func TestLabelingStrategy_Enforce(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "Equals", Target: 2000, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "Equals", Target: 200, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "Equals", Target: resource.MustParse("2000"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{"telemetry.aware.scheduling.labeling-test/gpu-device": "card0"}}},
@@ -113,8 +113,8 @@ func TestLabelingStrategy_Enforce(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "Equals", Target: 2000, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "Equals", Target: 200, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "Equals", Target: resource.MustParse("2000"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{"telemetry.aware.scheduling.labeling-test/gpu-device": "card0"}}},
@@ -185,8 +185,8 @@ func TestLabelingStrategy_Enforce_unsupportedCases(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 200, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "LessThan", Target: 200, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "LessThan", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -195,8 +195,8 @@ func TestLabelingStrategy_Enforce_unsupportedCases(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "LessThan", Target: 2000, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "Equals", Target: 200, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "LessThan", Target: resource.MustParse("2000"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -205,8 +205,8 @@ func TestLabelingStrategy_Enforce_unsupportedCases(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "labeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 20, Labels: []string{"gpu-device=card1"}},
-					{Metricname: "cpu", Operator: "Equals", Target: 200, Labels: []string{"gpu-device=card0"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("20"), Labels: []string{"gpu-device=card1"}},
+					{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card0"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.labeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -278,7 +278,7 @@ func TestLabelingStrategy_Cleanup(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "unlabeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 99, Labels: []string{"gpu-card1=false"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("99"), Labels: []string{"gpu-card1=false"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.unlabeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -288,7 +288,7 @@ func TestLabelingStrategy_Cleanup(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "unlabeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 3000, Labels: []string{"gpu-card0=false"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("3000"), Labels: []string{"gpu-card0=false"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.unlabeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -298,8 +298,8 @@ func TestLabelingStrategy_Cleanup(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "unlabeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 99, Labels: []string{"gpu-card1=false"}},
-					{Metricname: "cpu", Operator: "GreaterThan", Target: 10, Labels: []string{"gpu-card2=true"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("99"), Labels: []string{"gpu-card1=false"}},
+					{Metricname: "cpu", Operator: "GreaterThan", Target: resource.MustParse("10"), Labels: []string{"gpu-card2=true"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.unlabeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -309,8 +309,8 @@ func TestLabelingStrategy_Cleanup(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "unlabeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "GreaterThan", Target: 100, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "GreaterThan", Target: 100, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("100"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "GreaterThan", Target: resource.MustParse("100"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.unlabeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -320,8 +320,8 @@ func TestLabelingStrategy_Cleanup(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "unlabeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "LessThan", Target: 10000, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "LessThan", Target: 10000, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "LessThan", Target: resource.MustParse("10000"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "LessThan", Target: resource.MustParse("10000"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.unlabeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -331,8 +331,8 @@ func TestLabelingStrategy_Cleanup(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "unlabeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "Equals", Target: 2000, Labels: []string{"gpu-device=card1"}},
-					{Metricname: "cpu", Operator: "Equals", Target: 200, Labels: []string{"gpu-device=card0"}}}},
+					{Metricname: "memory", Operator: "Equals", Target: resource.MustParse("2000"), Labels: []string{"gpu-device=card1"}},
+					{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card0"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.unlabeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -342,8 +342,8 @@ func TestLabelingStrategy_Cleanup(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "unlabeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "Equals", Target: 2000, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "Equals", Target: 200, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "Equals", Target: resource.MustParse("2000"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.unlabeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -353,8 +353,8 @@ func TestLabelingStrategy_Cleanup(t *testing.T) {
 			d: &Strategy{
 				PolicyName: "unlabeling-test",
 				Rules: []telpol.TASPolicyRule{
-					{Metricname: "memory", Operator: "Equals", Target: 2000, Labels: []string{"gpu-device=card0"}},
-					{Metricname: "cpu", Operator: "Equals", Target: 200, Labels: []string{"gpu-device=card1"}}}},
+					{Metricname: "memory", Operator: "Equals", Target: resource.MustParse("2000"), Labels: []string{"gpu-device=card0"}},
+					{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("200"), Labels: []string{"gpu-device=card1"}}}},
 			node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"telemetry.aware.scheduling.unlabeling-test": ""}}},
 			args: args{enforcer: strategy.NewEnforcer(testclient.NewSimpleClientset()), cache: cache.MockEmptySelfUpdatingCache()},
 			want: expected{nodeNames: []string{"node-1"}, nodeLabels: map[string]string{}}},
@@ -5,8 +5,10 @@ package labeling
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
 	telempol "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -39,9 +41,28 @@ type violationResultType struct {
 // the target in labeling strategy.
 // Returns a map of nodeNames as key with a slice of violated rules and metric quantities in the result type.
 func (d *Strategy) Violated(cache cache.Reader) map[string]interface{} {
+	return d.violated(cache, func(_ int, _ string, rule telempol.TASPolicyRule, nodeMetric metrics.NodeMetric) bool {
+		return core.EvaluateMetric(nodeMetric, rule)
+	})
+}
+
+// enforcedViolations is like Violated, but rules are evaluated against the rule state kept by the enforcer, so
+// that their for durations, samples and clear targets are honored.
+func (d *Strategy) enforcedViolations(enforcer *core.MetricEnforcer, cache cache.Reader) map[string]interface{} {
+	return d.violated(cache, func(ruleIndex int, nodeName string, rule telempol.TASPolicyRule,
+		nodeMetric metrics.NodeMetric) bool {
+		return enforcer.RuleViolated(d.PolicyName, StrategyType, ruleIndex, nodeName, rule, nodeMetric)
+	})
+}
+
+// violated returns the nodes violating the strategy with their violated rules, with the rules evaluated by the
+// given function.
+func (d *Strategy) violated(cache cache.Reader,
+	evaluate func(ruleIndex int, nodeName string, rule telempol.TASPolicyRule, nodeMetric metrics.NodeMetric) bool,
+) map[string]interface{} {
 	violatingNodes := map[string]interface{}{}
 
-	for _, rule := range d.Rules {
+	for i, rule := range d.Rules {
 		nodeMetrics, err := cache.ReadMetric(rule.Metricname)
 		if err != nil {
 			klog.V(2).InfoS(err.Error(), "component", "controller")
@@ -53,7 +74,7 @@ func (d *Strategy) Violated(cache cache.Reader) map[string]interface{} {
 			msg := fmt.Sprint(nodeName+" "+rule.Metricname, " = ", nodeMetric.Value.AsDec())
 			klog.V(4).InfoS(msg, "component", "controller")
 
-			if core.EvaluateRule(nodeMetric.Value, rule) {
+			if evaluate(i, nodeName, rule, nodeMetric) {
 				msg := fmt.Sprintf(nodeName + " violating " + d.PolicyName + ": " + ruleToString(rule))
 				klog.V(2).InfoS(msg, "component", "controller")
 
@@ -95,7 +116,7 @@ func (d *Strategy) Violated(cache cache.Reader) map[string]interface{} {
 
 // ruleToString returns the rule passed to it as a single string.
 func ruleToString(rule telempol.TASPolicyRule) string {
-	return fmt.Sprintf("%v %v %v %v", rule.Metricname, rule.Operator, rule.Target, rule.Labels)
+	return fmt.Sprintf("%v %v %v %v", rule.Metricname, rule.Operator, rule.Target.String(), rule.Labels)
 }
 
 func equalRules(a, b *telempol.TASPolicyRule) bool {
@@ -103,7 +124,7 @@ func equalRules(a, b *telempol.TASPolicyRule) bool {
 		return false
 	}
 
-	if a.Target != b.Target {
+	if a.Target.Cmp(b.Target) != 0 {
 		return false
 	}
 
@@ -150,3 +171,22 @@ func (d *Strategy) GetPolicyName() string {
 func (d *Strategy) SetPolicyName(name string) {
 	d.PolicyName = name
 }
+
+// Validate checks the logical operator and the rules of the strategy, and that the labels of the rules are in
+// key=value form.
+func (d *Strategy) Validate() error {
+	if err := core.ValidateRules(d.LogicalOperator, d.Rules); err != nil {
+		return err
+	}
+
+	for _, rule := range d.Rules {
+		for _, label := range rule.Labels {
+			if len(strings.Split(label, "=")) != 2 {
+				return fmt.Errorf("rule for %v has an invalid label %q, labels must be in key=value form",
+					rule.Metricname, label)
+			}
+		}
+	}
+
+	return nil
+}
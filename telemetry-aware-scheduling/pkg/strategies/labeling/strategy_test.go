@@ -70,20 +70,20 @@ func TestLabelingStrategy_Equals(t *testing.T) {
 		args args
 		want bool
 	}{
-		{name: "Not Equal: one empty strategies", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}},
-		{name: "Equal: one rule per strategy", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}}, want: true},
-		{name: "Equal: 2 different rules same order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: 1, Labels: []string{"card0=false"}}, {Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: 1, Labels: []string{"card0=false"}}, {Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}}, want: true},
-		{name: "Not equal: 2 different rules different order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}, {Metricname: "cpu", Operator: "Equals", Target: 1, Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: 1, Labels: []string{"card0=false"}}, {Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}}, want: false},
-		{name: "Not equal: different number rules", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: 1, Labels: []string{"card0=false"}}, {Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}}, want: false},
-		{name: "Not equal: different number rules different order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}, {Metricname: "cpu", Operator: "Equals", Target: 1, Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50}}}}, want: false},
-		{name: "Not equal: different rules", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}}, want: false},
-		{name: "Not equal: different operator", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "LessThan", Target: 50, Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=false"}}}}}, want: false},
-		{name: "Not equal: different target", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "LessThan", Target: 10, Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=false"}}}}}, want: false},
-		{name: "Not equal: different metrics", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=false"}}}}}, want: false},
-		{name: "Not equal: different labels", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=false"}}}}}, want: false},
-		{name: "Equal: 2 labels same order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true", "card1=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true", "card1=false"}}}}}, want: true},
-		{name: "Not Equal: 2 labels different order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card1=false", "card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true", "card1=false"}}}}}, want: false},
-		{name: "Not Equal: different number of labels", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card1=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: 50, Labels: []string{"card0=true", "card1=false"}}}}}, want: false},
+		{name: "Not Equal: one empty strategies", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}},
+		{name: "Equal: one rule per strategy", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}}, want: true},
+		{name: "Equal: 2 different rules same order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("1"), Labels: []string{"card0=false"}}, {Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("1"), Labels: []string{"card0=false"}}, {Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}}, want: true},
+		{name: "Not equal: 2 different rules different order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}, {Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("1"), Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("1"), Labels: []string{"card0=false"}}, {Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}}, want: false},
+		{name: "Not equal: different number rules", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("1"), Labels: []string{"card0=false"}}, {Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}}, want: false},
+		{name: "Not equal: different number rules different order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}, {Metricname: "cpu", Operator: "Equals", Target: resource.MustParse("1"), Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50")}}}}, want: false},
+		{name: "Not equal: different rules", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}}, want: false},
+		{name: "Not equal: different operator", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "LessThan", Target: resource.MustParse("50"), Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=false"}}}}}, want: false},
+		{name: "Not equal: different target", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "LessThan", Target: resource.MustParse("10"), Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=false"}}}}}, want: false},
+		{name: "Not equal: different metrics", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "cpu", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=false"}}}}}, want: false},
+		{name: "Not equal: different labels", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=false"}}}}}, want: false},
+		{name: "Equal: 2 labels same order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true", "card1=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true", "card1=false"}}}}}, want: true},
+		{name: "Not Equal: 2 labels different order", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card1=false", "card0=true"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true", "card1=false"}}}}}, want: false},
+		{name: "Not Equal: different number of labels", d: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card1=false"}}}}, args: args{other: &Strategy{PolicyName: "test name", Rules: []v1.TASPolicyRule{{Metricname: "memory", Operator: "GreaterThan", Target: resource.MustParse("50"), Labels: []string{"card0=true", "card1=false"}}}}}, want: false},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -342,7 +342,7 @@ func metricRules(metricname string, operator string, target int64, labels []stri
 	return v1.TASPolicyRule{
 		Metricname: metricname,
 		Operator:   operator,
-		Target:     target,
+		Target:     *resource.NewQuantity(target, resource.DecimalSI),
 		Labels:     labels,
 	}
 }
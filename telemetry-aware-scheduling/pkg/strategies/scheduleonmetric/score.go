@@ -0,0 +1,125 @@
+package scheduleonmetric
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
+	telemetryPolicyV1 "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"k8s.io/klog/v2"
+)
+
+//Missing metric policies, which tell how nodes missing the metric of a rule are scored. PenalizeMissing is used
+//when the strategy doesn't set one.
+const (
+	PenalizeMissing = "penalize"
+	IgnoreMissing   = "ignore"
+	ExcludeMissing  = "exclude"
+)
+
+//MaxScore is the score of the best node for a rule.
+const MaxScore = 10
+
+//errNoNodeMetrics is returned when none of the nodes has a metric of the strategy.
+var errNoNodeMetrics = errors.New("no metrics found for the nodes")
+
+//Scores returns the scores of the named nodes. Each rule ranks the nodes which have its metric in the order of its
+//operator: the first node scores MaxScore, the next one less by one and so on down to zero, with equal values
+//scoring the same. The score of a node is the mean of its rule scores weighted by the rule weights.
+//A node missing the metric of a rule scores zero for it, unless the strategy ignores missing metrics, in which case
+//the rule doesn't count for the node, or excludes them, in which case the node isn't scored. Nodes without any of
+//the metrics aren't scored either.
+func (d *Strategy) Scores(cache cache.Reader, nodeNames []string) (map[string]int, error) {
+	weightedSums := map[string]float64{}
+	nodeWeights := map[string]int32{}
+	totalWeight := int32(0)
+	for _, rule := range d.Rules {
+		weight := ruleWeight(rule)
+		totalWeight += weight
+		for nodeName, score := range rankScores(nodeMetrics(cache, rule, nodeNames), rule) {
+			weightedSums[nodeName] += float64(weight) * float64(score)
+			nodeWeights[nodeName] += weight
+		}
+	}
+	scores := map[string]int{}
+	for nodeName, weightedSum := range weightedSums {
+		weight := totalWeight
+		switch d.MissingMetric {
+		case IgnoreMissing:
+			weight = nodeWeights[nodeName]
+		case ExcludeMissing:
+			if nodeWeights[nodeName] != totalWeight {
+				continue
+			}
+		}
+		scores[nodeName] = int(math.Round(weightedSum / float64(weight)))
+	}
+	if len(scores) == 0 {
+		return nil, errNoNodeMetrics
+	}
+	return scores, nil
+}
+
+//Excluded returns the named nodes which miss the metric of a rule, if the strategy excludes those.
+func (d *Strategy) Excluded(cache cache.Reader, nodeNames []string) map[string]interface{} {
+	excluded := map[string]interface{}{}
+	if d.MissingMetric != ExcludeMissing {
+		return excluded
+	}
+	for _, rule := range d.Rules {
+		ruleMetrics := nodeMetrics(cache, rule, nodeNames)
+		for _, nodeName := range nodeNames {
+			if _, ok := ruleMetrics[nodeName]; !ok {
+				excluded[nodeName] = nil
+			}
+		}
+	}
+	return excluded
+}
+
+//nodeMetrics returns the metric of the rule for those of the named nodes which have it.
+func nodeMetrics(cache cache.Reader, rule telemetryPolicyV1.TASPolicyRule, nodeNames []string) metrics.NodeMetricsInfo {
+	filteredNodeData := metrics.NodeMetricsInfo{}
+	nodeData, err := cache.ReadMetric(rule.Metricname)
+	if err != nil {
+		klog.V(2).InfoS("Nodes not scored by "+rule.Metricname+": "+err.Error(), "component", "extender")
+		return filteredNodeData
+	}
+	for _, nodeName := range nodeNames {
+		if v, ok := nodeData[nodeName]; ok {
+			filteredNodeData[nodeName] = v
+		}
+	}
+	return filteredNodeData
+}
+
+//rankScores returns the score of each node for a rule, by its rank in the order of the rule operator.
+func rankScores(nodeData metrics.NodeMetricsInfo, rule telemetryPolicyV1.TASPolicyRule) map[string]int {
+	scores := map[string]int{}
+	metricsOutput := fmt.Sprintf("%v for nodes: ", rule.Metricname)
+	orderedNodes := core.OrderedList(nodeData, rule.Operator)
+	for i, node := range orderedNodes {
+		metricsOutput = fmt.Sprint(metricsOutput, " [ ", node.NodeName, " :", node.MetricValue.AsDec(), "]")
+		score := MaxScore - i
+		if i > 0 && node.MetricValue.Cmp(orderedNodes[i-1].MetricValue) == 0 {
+			score = scores[orderedNodes[i-1].NodeName]
+		}
+		if score < 0 {
+			score = 0
+		}
+		scores[node.NodeName] = score
+	}
+	klog.V(2).InfoS(metricsOutput, "component", "extender")
+	return scores
+}
+
+//ruleWeight returns the weight of the rule, which is 1 when it isn't set.
+func ruleWeight(rule telemetryPolicyV1.TASPolicyRule) int32 {
+	if rule.Weight == 0 {
+		return 1
+	}
+	return rule.Weight
+}
@@ -0,0 +1,95 @@
+package scheduleonmetric
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+)
+
+//scoreTestCache holds power, temperature and bandwidth metrics, where node C has no temperature metric.
+func scoreTestCache(t *testing.T) cache.ReaderWriter {
+	n := cache.MockEmptySelfUpdatingCache()
+	testMetrics := map[string][]int64{
+		"power":       {100, 200, 300},
+		"temperature": {60, 50},
+		"bandwidth":   {10, 30, 20},
+	}
+	for metricName, values := range testMetrics {
+		nodeNames := []string{"node A", "node B", "node C"}[:len(values)]
+		if err := n.WriteMetric(metricName, cache.TestNodeMetricCustomInfo(nodeNames, values)); err != nil {
+			t.Fatalf("Cannot write metric to mock cache for test: %v", err)
+		}
+	}
+	return n
+}
+
+func TestScheduleOnMetricStrategy_Scores(t *testing.T) {
+	rule := func(metricName, operator string, weight int32) telemetrypolicy.TASPolicyRule {
+		return telemetrypolicy.TASPolicyRule{Metricname: metricName, Operator: operator, Weight: weight}
+	}
+	tests := []struct {
+		name    string
+		d       Strategy
+		want    map[string]int
+		wantErr bool
+	}{
+		{"single rule ranks the nodes",
+			Strategy{Rules: []telemetrypolicy.TASPolicyRule{rule("power", "LessThan", 0)}},
+			map[string]int{"node A": 10, "node B": 9, "node C": 8}, false},
+		{"rules are combined by weight",
+			Strategy{Rules: []telemetrypolicy.TASPolicyRule{rule("power", "LessThan", 1), rule("bandwidth", "GreaterThan", 3)}},
+			map[string]int{"node A": 9, "node B": 10, "node C": 9}, false},
+		{"missing metric is penalized by default",
+			Strategy{Rules: []telemetrypolicy.TASPolicyRule{rule("power", "GreaterThan", 0), rule("temperature", "LessThan", 0)}},
+			map[string]int{"node A": 9, "node B": 10, "node C": 5}, false},
+		{"missing metric is ignored",
+			Strategy{MissingMetric: IgnoreMissing,
+				Rules: []telemetrypolicy.TASPolicyRule{rule("power", "GreaterThan", 0), rule("temperature", "LessThan", 0)}},
+			map[string]int{"node A": 9, "node B": 10, "node C": 10}, false},
+		{"node missing a metric is excluded",
+			Strategy{MissingMetric: ExcludeMissing,
+				Rules: []telemetrypolicy.TASPolicyRule{rule("power", "GreaterThan", 0), rule("temperature", "LessThan", 0)}},
+			map[string]int{"node A": 9, "node B": 10}, false},
+		{"no node has a metric",
+			Strategy{Rules: []telemetrypolicy.TASPolicyRule{rule("missing", "GreaterThan", 0)}},
+			nil, true},
+	}
+	metricCache := scoreTestCache(t)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.Scores(metricCache, []string{"node A", "node B", "node C", "node D"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scores() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scores() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleOnMetricStrategy_Excluded(t *testing.T) {
+	rules := []telemetrypolicy.TASPolicyRule{{Metricname: "power", Operator: "GreaterThan"},
+		{Metricname: "temperature", Operator: "LessThan"}}
+	tests := []struct {
+		name string
+		d    Strategy
+		want map[string]interface{}
+	}{
+		{"nodes missing a metric are excluded", Strategy{MissingMetric: ExcludeMissing, Rules: rules},
+			map[string]interface{}{"node C": nil, "node D": nil}},
+		{"nodes are kept when missing metrics are penalized", Strategy{Rules: rules}, map[string]interface{}{}},
+	}
+	metricCache := scoreTestCache(t)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Excluded(metricCache, []string{"node A", "node B", "node C", "node D"}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Excluded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
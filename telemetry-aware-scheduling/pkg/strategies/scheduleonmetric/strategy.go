@@ -3,6 +3,8 @@
 package scheduleonmetric
 
 import (
+	"fmt"
+
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
 	telemetryPolicyV1 "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
@@ -42,7 +44,7 @@ func (d *Strategy) Equals(other core.Interface) bool {
 			if rule.Metricname != otherScheduleOnMetricStrategy.Rules[i].Metricname {
 				return false
 			}
-			if rule.Target != otherScheduleOnMetricStrategy.Rules[i].Target {
+			if rule.Target.Cmp(otherScheduleOnMetricStrategy.Rules[i].Target) != 0 {
 				return false
 			}
 			if rule.Operator != otherScheduleOnMetricStrategy.Rules[i].Operator {
@@ -64,3 +66,29 @@ func (d *Strategy) GetPolicyName() string {
 func (d *Strategy) SetPolicyName(policyName string) {
 	d.PolicyName = policyName
 }
+
+//Validate checks that the strategy has rules to score the nodes by. Each rule needs an operator the nodes can be
+//ordered by, and a weight which isn't negative.
+func (d *Strategy) Validate() error {
+	if len(d.Rules) == 0 {
+		return core.ErrNoRules
+	}
+	if err := core.ValidateRules(d.LogicalOperator, d.Rules); err != nil {
+		return err
+	}
+	for _, rule := range d.Rules {
+		if !core.Orderable(rule.Operator) {
+			return fmt.Errorf("invalid operator %q, nodes are ordered by GreaterThan, GreaterOrEqual, LessThan or LessOrEqual",
+				rule.Operator)
+		}
+		if rule.Weight < 0 {
+			return fmt.Errorf("rule for %v has a negative weight", rule.Metricname)
+		}
+	}
+	switch d.MissingMetric {
+	case "", PenalizeMissing, IgnoreMissing, ExcludeMissing:
+	default:
+		return fmt.Errorf("invalid missingMetric %q, must be penalize, ignore or exclude", d.MissingMetric)
+	}
+	return core.ValidateStateless(d.Rules)
+}
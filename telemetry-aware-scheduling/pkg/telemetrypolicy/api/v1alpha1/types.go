@@ -2,6 +2,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,14 +27,27 @@ type TASPolicyStrategy struct {
 	PolicyName      string          `json:"policyName"`
 	LogicalOperator string          `json:"logicalOperator,omitempty"`
 	Rules           []TASPolicyRule `json:"rules"`
+	// MissingMetric tells how the scheduleonmetric strategy scores nodes which miss the metric of a rule:
+	// "penalize" (the default), "ignore" or "exclude".
+	MissingMetric string `json:"missingMetric,omitempty"`
 }
 
 // TASPolicyRule contains the parameters for the strategy rule.
 type TASPolicyRule struct {
-	Metricname string   `json:"metricname"`
-	Operator   string   `json:"operator"`
-	Target     int64    `json:"target"`
-	Labels     []string `json:"labels,omitempty"`
+	Metricname string             `json:"metricname"`
+	Operator   string             `json:"operator"`
+	Target     resource.Quantity  `json:"target"`
+	Lower      *resource.Quantity `json:"lower,omitempty"`
+	Upper      *resource.Quantity `json:"upper,omitempty"`
+	Labels     []string           `json:"labels,omitempty"`
+	// For and ForSamples hold how long, and for how many consecutive samples, a node has to break the rule before
+	// it violates it. ClearTarget is the target which the node has to stop breaking for the violation to end,
+	// instead of Target. They are used by the deschedule and labeling strategies.
+	For         *metav1.Duration   `json:"for,omitempty"`
+	ForSamples  int32              `json:"forSamples,omitempty"`
+	ClearTarget *resource.Quantity `json:"clearTarget,omitempty"`
+	// Weight is how much the rule counts in the node scores of the scheduleonmetric strategy. It defaults to 1.
+	Weight int32 `json:"weight,omitempty"`
 }
 
 // TASPolicySpec is a map of strategies indexed by their strategy type name i.e. scheduleonmetric, dontschedule.
@@ -41,9 +55,25 @@ type TASPolicySpec struct {
 	Strategies map[string]TASPolicyStrategy `json:"strategies"`
 }
 
-// TASPolicyStatus defines the observed state of TASpolicy. Currently no status object is implemented.
-// TODO: Implement policy status object.
+// TASPolicyStatus defines the observed state of TASpolicy, as written by the TAS controller.
 type TASPolicyStatus struct {
+	Strategies map[string]TASPolicyStrategyStatus `json:"strategies,omitempty"`
+}
+
+// TASPolicyStrategyStatus is the observed state of a strategy, indexed by its strategy type name in TASPolicyStatus.
+// LastEnforced and LastError are only set for strategies which are enforced, i.e. deschedule and labeling.
+type TASPolicyStrategyStatus struct {
+	LastEnforced   *metav1.Time          `json:"lastEnforced,omitempty"`
+	LastError      string                `json:"lastError,omitempty"`
+	ViolatingNodes []string              `json:"violatingNodes,omitempty"`
+	Rules          []TASPolicyRuleStatus `json:"rules,omitempty"`
+}
+
+// TASPolicyRuleStatus is the observed state of the metric of a rule, in the order of the rules of the strategy.
+type TASPolicyRuleStatus struct {
+	Metricname    string       `json:"metricname"`
+	MetricPresent bool         `json:"metricPresent"`
+	LastUpdated   *metav1.Time `json:"lastUpdated,omitempty"`
 }
 
 // TASPolicyList contains a list of TASpolicy.
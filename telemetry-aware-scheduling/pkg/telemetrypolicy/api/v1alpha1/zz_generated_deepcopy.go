@@ -10,7 +10,7 @@ func (in *TASPolicy) DeepCopyInto(out *TASPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 
 }
 
@@ -83,6 +83,13 @@ func (in *TASPolicySpec) DeepCopy() *TASPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TASPolicyStatus) DeepCopyInto(out *TASPolicyStatus) {
 	*out = *in
+	if in.Strategies != nil {
+		in, out := &in.Strategies, &out.Strategies
+		*out = make(map[string]TASPolicyStrategyStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyStatus.
@@ -94,3 +101,53 @@ func (in *TASPolicyStatus) DeepCopy() *TASPolicyStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyStrategyStatus) DeepCopyInto(out *TASPolicyStrategyStatus) {
+	*out = *in
+	if in.LastEnforced != nil {
+		in, out := &in.LastEnforced, &out.LastEnforced
+		*out = (*in).DeepCopy()
+	}
+	if in.ViolatingNodes != nil {
+		in, out := &in.ViolatingNodes, &out.ViolatingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]TASPolicyRuleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyStrategyStatus.
+func (in *TASPolicyStrategyStatus) DeepCopy() *TASPolicyStrategyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyStrategyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyRuleStatus) DeepCopyInto(out *TASPolicyRuleStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyRuleStatus.
+func (in *TASPolicyRuleStatus) DeepCopy() *TASPolicyRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,153 @@
+package v1beta1
+
+import (
+	"sort"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// strategyFields returns the strategy fields by their strategy type name, which is the key of the strategy in
+// the v1alpha1 strategy map.
+func (in *TASPolicyStrategies) strategyFields() map[string]**TASPolicyStrategy {
+	return map[string]**TASPolicyStrategy{
+		"deschedule":       &in.Deschedule,
+		"dontschedule":     &in.DontSchedule,
+		"scheduleonmetric": &in.ScheduleOnMetric,
+		"labeling":         &in.Labeling,
+	}
+}
+
+// ConvertFromV1alpha1 returns the v1beta1 form of a v1alpha1 policy. The type meta is left for the caller to set.
+// Strategies of unknown type, which v1beta1 cannot hold, are dropped rather than failing the conversion, so that
+// policies stored before v1beta1 stay readable. Their types are returned, sorted, for the caller to warn about.
+func ConvertFromV1alpha1(in *v1alpha1.TASPolicy) (*TASPolicy, []string) {
+	out := &TASPolicy{}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	fields := out.Spec.Strategies.strategyFields()
+	dropped := []string{}
+	for name, inStrategy := range in.Spec.Strategies {
+		field, ok := fields[name]
+		if !ok {
+			dropped = append(dropped, name)
+			continue
+		}
+		outStrategy := &TASPolicyStrategy{
+			LogicalOperator: LogicalOperator(inStrategy.LogicalOperator),
+			MissingMetric:   MissingMetricPolicy(inStrategy.MissingMetric),
+		}
+		for _, rule := range inStrategy.Rules {
+			outStrategy.Rules = append(outStrategy.Rules, TASPolicyRule{
+				Metricname:  rule.Metricname,
+				Operator:    Operator(rule.Operator),
+				Target:      rule.Target.DeepCopy(),
+				Lower:       copyQuantity(rule.Lower),
+				Upper:       copyQuantity(rule.Upper),
+				Labels:      append([]string(nil), rule.Labels...),
+				For:         copyDuration(rule.For),
+				ForSamples:  rule.ForSamples,
+				ClearTarget: copyQuantity(rule.ClearTarget),
+				Weight:      rule.Weight,
+			})
+		}
+		*field = outStrategy
+	}
+	out.Status = ConvertStatusFromV1alpha1(&in.Status)
+	sort.Strings(dropped)
+	return out, dropped
+}
+
+// ConvertToV1alpha1 returns the v1alpha1 form of a policy. The type meta is left for the caller to set.
+// The policy name of each strategy is the name of the policy.
+func ConvertToV1alpha1(in *TASPolicy) *v1alpha1.TASPolicy {
+	out := &v1alpha1.TASPolicy{Spec: v1alpha1.TASPolicySpec{Strategies: map[string]v1alpha1.TASPolicyStrategy{}}}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	strategies := in.DeepCopy().Spec.Strategies
+	for name, field := range strategies.strategyFields() {
+		inStrategy := *field
+		if inStrategy == nil {
+			continue
+		}
+		outStrategy := v1alpha1.TASPolicyStrategy{
+			PolicyName:      in.Name,
+			LogicalOperator: string(inStrategy.LogicalOperator),
+			MissingMetric:   string(inStrategy.MissingMetric),
+		}
+		for _, rule := range inStrategy.Rules {
+			outStrategy.Rules = append(outStrategy.Rules, v1alpha1.TASPolicyRule{
+				Metricname:  rule.Metricname,
+				Operator:    string(rule.Operator),
+				Target:      rule.Target,
+				Lower:       rule.Lower,
+				Upper:       rule.Upper,
+				Labels:      rule.Labels,
+				For:         rule.For,
+				ForSamples:  rule.ForSamples,
+				ClearTarget: rule.ClearTarget,
+				Weight:      rule.Weight,
+			})
+		}
+		out.Spec.Strategies[name] = outStrategy
+	}
+	out.Status = convertStatusToV1alpha1(&in.Status)
+	return out
+}
+
+// ConvertStatusFromV1alpha1 returns the v1beta1 form of a v1alpha1 policy status.
+func ConvertStatusFromV1alpha1(in *v1alpha1.TASPolicyStatus) TASPolicyStatus {
+	out := TASPolicyStatus{}
+	for name, inStatus := range in.Strategies {
+		if out.Strategies == nil {
+			out.Strategies = map[string]TASPolicyStrategyStatus{}
+		}
+		outStatus := TASPolicyStrategyStatus{
+			LastEnforced:   inStatus.LastEnforced.DeepCopy(),
+			LastError:      inStatus.LastError,
+			ViolatingNodes: append([]string(nil), inStatus.ViolatingNodes...),
+		}
+		for _, rule := range inStatus.Rules {
+			outStatus.Rules = append(outStatus.Rules, TASPolicyRuleStatus(*rule.DeepCopy()))
+		}
+		out.Strategies[name] = outStatus
+	}
+	return out
+}
+
+// convertStatusToV1alpha1 returns the v1alpha1 form of a policy status.
+func convertStatusToV1alpha1(in *TASPolicyStatus) v1alpha1.TASPolicyStatus {
+	out := v1alpha1.TASPolicyStatus{}
+	for name, inStatus := range in.Strategies {
+		if out.Strategies == nil {
+			out.Strategies = map[string]v1alpha1.TASPolicyStrategyStatus{}
+		}
+		outStatus := v1alpha1.TASPolicyStrategyStatus{
+			LastEnforced:   inStatus.LastEnforced.DeepCopy(),
+			LastError:      inStatus.LastError,
+			ViolatingNodes: append([]string(nil), inStatus.ViolatingNodes...),
+		}
+		for _, rule := range inStatus.Rules {
+			outStatus.Rules = append(outStatus.Rules, v1alpha1.TASPolicyRuleStatus(*rule.DeepCopy()))
+		}
+		out.Strategies[name] = outStatus
+	}
+	return out
+}
+
+// copyQuantity returns a deep copy of an optional quantity.
+func copyQuantity(in *resource.Quantity) *resource.Quantity {
+	if in == nil {
+		return nil
+	}
+	out := in.DeepCopy()
+	return &out
+}
+
+// copyDuration returns a copy of an optional duration.
+func copyDuration(in *metav1.Duration) *metav1.Duration {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
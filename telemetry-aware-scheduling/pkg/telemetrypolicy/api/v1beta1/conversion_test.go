@@ -0,0 +1,137 @@
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertFromV1alpha1(t *testing.T) {
+	lastEnforced := metav1.Unix(100, 0)
+	meta := metav1.ObjectMeta{Name: "demo-policy", Namespace: "default"}
+	lower, upper := resource.MustParse("10"), resource.MustParse("90")
+	forDuration, clearTarget := metav1.Duration{Duration: time.Minute}, resource.MustParse("70")
+	tests := []struct {
+		name        string
+		in          *v1alpha1.TASPolicy
+		want        *TASPolicy
+		wantDropped []string
+	}{
+		{"strategies, rules and status are converted",
+			&v1alpha1.TASPolicy{ObjectMeta: meta,
+				Spec: v1alpha1.TASPolicySpec{Strategies: map[string]v1alpha1.TASPolicyStrategy{
+					"deschedule": {PolicyName: "demo-policy", LogicalOperator: "allOf", Rules: []v1alpha1.TASPolicyRule{
+						{Metricname: "temperature", Operator: "GreaterThan", Target: *resource.NewQuantity(80, resource.DecimalSI),
+							For: &forDuration, ForSamples: 3, ClearTarget: &clearTarget},
+					}},
+					"dontschedule": {PolicyName: "demo-policy", Rules: []v1alpha1.TASPolicyRule{
+						{Metricname: "load", Operator: "OutOfRange", Lower: &lower, Upper: &upper},
+					}},
+					"scheduleonmetric": {PolicyName: "demo-policy", MissingMetric: "ignore", Rules: []v1alpha1.TASPolicyRule{
+						{Metricname: "load", Operator: "LessThan", Weight: 2},
+					}},
+					"labeling": {PolicyName: "demo-policy", Rules: []v1alpha1.TASPolicyRule{
+						{Metricname: "temperature", Operator: "GreaterThan", Target: *resource.NewQuantity(90, resource.DecimalSI),
+							Labels: []string{"hot=true"}},
+					}},
+				}},
+				Status: v1alpha1.TASPolicyStatus{Strategies: map[string]v1alpha1.TASPolicyStrategyStatus{
+					"deschedule": {LastEnforced: &lastEnforced, ViolatingNodes: []string{"node-1"},
+						Rules: []v1alpha1.TASPolicyRuleStatus{{Metricname: "temperature", MetricPresent: true}}},
+				}},
+			},
+			&TASPolicy{ObjectMeta: meta,
+				Spec: TASPolicySpec{Strategies: TASPolicyStrategies{
+					Deschedule: &TASPolicyStrategy{LogicalOperator: AllOf, Rules: []TASPolicyRule{
+						{Metricname: "temperature", Operator: GreaterThan, Target: *resource.NewQuantity(80, resource.DecimalSI),
+							For: &forDuration, ForSamples: 3, ClearTarget: &clearTarget},
+					}},
+					DontSchedule: &TASPolicyStrategy{Rules: []TASPolicyRule{
+						{Metricname: "load", Operator: OutOfRange, Lower: &lower, Upper: &upper},
+					}},
+					ScheduleOnMetric: &TASPolicyStrategy{MissingMetric: Ignore, Rules: []TASPolicyRule{
+						{Metricname: "load", Operator: LessThan, Weight: 2},
+					}},
+					Labeling: &TASPolicyStrategy{Rules: []TASPolicyRule{
+						{Metricname: "temperature", Operator: GreaterThan, Target: *resource.NewQuantity(90, resource.DecimalSI),
+							Labels: []string{"hot=true"}},
+					}},
+				}},
+				Status: TASPolicyStatus{Strategies: map[string]TASPolicyStrategyStatus{
+					"deschedule": {LastEnforced: &lastEnforced, ViolatingNodes: []string{"node-1"},
+						Rules: []TASPolicyRuleStatus{{Metricname: "temperature", MetricPresent: true}}},
+				}},
+			},
+			[]string{},
+		},
+		{"strategies of unknown type are dropped",
+			&v1alpha1.TASPolicy{ObjectMeta: meta,
+				Spec: v1alpha1.TASPolicySpec{Strategies: map[string]v1alpha1.TASPolicyStrategy{
+					"scheduleanywhere": {PolicyName: "demo-policy"},
+					"dontschedule": {PolicyName: "demo-policy", Rules: []v1alpha1.TASPolicyRule{
+						{Metricname: "load", Operator: "GreaterThan", Target: *resource.NewQuantity(80, resource.DecimalSI)},
+					}},
+					"deschedulesoon": {PolicyName: "demo-policy"},
+				}},
+			},
+			&TASPolicy{ObjectMeta: meta,
+				Spec: TASPolicySpec{Strategies: TASPolicyStrategies{
+					DontSchedule: &TASPolicyStrategy{Rules: []TASPolicyRule{
+						{Metricname: "load", Operator: GreaterThan, Target: *resource.NewQuantity(80, resource.DecimalSI)},
+					}},
+				}},
+			},
+			[]string{"deschedulesoon", "scheduleanywhere"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, dropped := ConvertFromV1alpha1(tt.in)
+			if !reflect.DeepEqual(dropped, tt.wantDropped) {
+				t.Errorf("ConvertFromV1alpha1() dropped = %v, want %v", dropped, tt.wantDropped)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertFromV1alpha1() = %+v, want %+v", got, tt.want)
+			}
+			if len(dropped) > 0 {
+				return
+			}
+			if back := ConvertToV1alpha1(got); !reflect.DeepEqual(back, tt.in) {
+				t.Errorf("ConvertToV1alpha1() = %+v, want %+v", back, tt.in)
+			}
+		})
+	}
+}
+
+func TestConvertToV1alpha1(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{"integer target", "80"},
+		{"fractional target", "850m"},
+		{"suffixed target", "1536Mi"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			in := &TASPolicy{ObjectMeta: metav1.ObjectMeta{Name: "demo-policy"},
+				Spec: TASPolicySpec{Strategies: TASPolicyStrategies{ScheduleOnMetric: &TASPolicyStrategy{
+					Rules: []TASPolicyRule{{Metricname: "load", Operator: LessThan, Target: resource.MustParse(tt.target)}},
+				}}},
+			}
+			got := ConvertToV1alpha1(in).Spec.Strategies["scheduleonmetric"]
+			if got.PolicyName != "demo-policy" {
+				t.Errorf("policy name = %v, want demo-policy", got.PolicyName)
+			}
+			if want := resource.MustParse(tt.target); got.Rules[0].Target.Cmp(want) != 0 {
+				t.Errorf("target = %v, want %v", got.Rules[0].Target.String(), tt.target)
+			}
+		})
+	}
+}
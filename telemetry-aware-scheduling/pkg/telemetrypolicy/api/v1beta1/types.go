@@ -0,0 +1,129 @@
+// Package v1beta1 describes the structure of the Telemetry Policy CRD, with typed strategies and quantity targets.
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defines key values for policy CRD.
+const (
+	Plural  = "taspolicies"
+	Group   = "telemetry.intel.com"
+	Version = "v1beta1"
+)
+
+// LogicalOperator tells how the rules of a strategy are combined.
+type LogicalOperator string
+
+// Logical operators of a strategy. AnyOf is used when none is given.
+const (
+	AllOf LogicalOperator = "allOf"
+	AnyOf LogicalOperator = "anyOf"
+)
+
+// Operator compares the value of a metric with the target of a rule.
+type Operator string
+
+// Operators of a rule. InRange and OutOfRange compare the value with the lower and upper bounds of the rule rather
+// than with its target. PercentChange compares the change since the previous sample, in percent, with the target.
+const (
+	Equals         Operator = "Equals"
+	NotEquals      Operator = "NotEquals"
+	LessThan       Operator = "LessThan"
+	LessOrEqual    Operator = "LessOrEqual"
+	GreaterThan    Operator = "GreaterThan"
+	GreaterOrEqual Operator = "GreaterOrEqual"
+	InRange        Operator = "InRange"
+	OutOfRange     Operator = "OutOfRange"
+	PercentChange  Operator = "PercentChange"
+)
+
+// MissingMetricPolicy tells how nodes which miss the metric of a scheduleonmetric rule are scored.
+type MissingMetricPolicy string
+
+// Missing metric policies. Penalize is used when none is given.
+const (
+	// Penalize gives nodes the lowest score for the rules whose metric they miss.
+	Penalize MissingMetricPolicy = "penalize"
+	// Ignore scores nodes by the rules whose metric they have.
+	Ignore MissingMetricPolicy = "ignore"
+	// Exclude filters out nodes which miss the metric of any rule.
+	Exclude MissingMetricPolicy = "exclude"
+)
+
+// TASPolicy is the Schema for the taspolicies API.
+type TASPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TASPolicySpec   `json:"spec"`
+	Status TASPolicyStatus `json:"status,omitempty"`
+}
+
+// TASPolicySpec holds the strategies of the policy.
+type TASPolicySpec struct {
+	Strategies TASPolicyStrategies `json:"strategies"`
+}
+
+// TASPolicyStrategies has a field for each strategy type. A policy sets the strategies it uses.
+type TASPolicyStrategies struct {
+	Deschedule       *TASPolicyStrategy `json:"deschedule,omitempty"`
+	DontSchedule     *TASPolicyStrategy `json:"dontschedule,omitempty"`
+	ScheduleOnMetric *TASPolicyStrategy `json:"scheduleonmetric,omitempty"`
+	Labeling         *TASPolicyStrategy `json:"labeling,omitempty"`
+}
+
+// TASPolicyStrategy contains a set of TASPolicyRule which define the strategy.
+type TASPolicyStrategy struct {
+	LogicalOperator LogicalOperator `json:"logicalOperator,omitempty"`
+	Rules           []TASPolicyRule `json:"rules"`
+	// MissingMetric tells how the scheduleonmetric strategy scores nodes which miss the metric of a rule.
+	MissingMetric MissingMetricPolicy `json:"missingMetric,omitempty"`
+}
+
+// TASPolicyRule contains the parameters for the strategy rule.
+type TASPolicyRule struct {
+	Metricname string             `json:"metricname"`
+	Operator   Operator           `json:"operator"`
+	Target     resource.Quantity  `json:"target"`
+	Lower      *resource.Quantity `json:"lower,omitempty"`
+	Upper      *resource.Quantity `json:"upper,omitempty"`
+	Labels     []string           `json:"labels,omitempty"`
+	// For and ForSamples hold how long, and for how many consecutive samples, a node has to break the rule before
+	// it violates it. ClearTarget is the target which the node has to stop breaking for the violation to end,
+	// instead of Target. They are used by the deschedule and labeling strategies.
+	For         *metav1.Duration   `json:"for,omitempty"`
+	ForSamples  int32              `json:"forSamples,omitempty"`
+	ClearTarget *resource.Quantity `json:"clearTarget,omitempty"`
+	// Weight is how much the rule counts in the node scores of the scheduleonmetric strategy. It defaults to 1.
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// TASPolicyStatus defines the observed state of TASpolicy, as written by the TAS controller.
+type TASPolicyStatus struct {
+	Strategies map[string]TASPolicyStrategyStatus `json:"strategies,omitempty"`
+}
+
+// TASPolicyStrategyStatus is the observed state of a strategy, indexed by its strategy type name in TASPolicyStatus.
+// LastEnforced and LastError are only set for strategies which are enforced, i.e. deschedule and labeling.
+type TASPolicyStrategyStatus struct {
+	LastEnforced   *metav1.Time          `json:"lastEnforced,omitempty"`
+	LastError      string                `json:"lastError,omitempty"`
+	ViolatingNodes []string              `json:"violatingNodes,omitempty"`
+	Rules          []TASPolicyRuleStatus `json:"rules,omitempty"`
+}
+
+// TASPolicyRuleStatus is the observed state of the metric of a rule, in the order of the rules of the strategy.
+type TASPolicyRuleStatus struct {
+	Metricname    string       `json:"metricname"`
+	MetricPresent bool         `json:"metricPresent"`
+	LastUpdated   *metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+// TASPolicyList contains a list of TASpolicy.
+type TASPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TASPolicy `json:"items"`
+}
@@ -0,0 +1,235 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicy) DeepCopyInto(out *TASPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicy.
+func (in *TASPolicy) DeepCopy() *TASPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TASPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyList) DeepCopyInto(out *TASPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TASPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyList.
+func (in *TASPolicyList) DeepCopy() *TASPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TASPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicySpec) DeepCopyInto(out *TASPolicySpec) {
+	*out = *in
+	in.Strategies.DeepCopyInto(&out.Strategies)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicySpec.
+func (in *TASPolicySpec) DeepCopy() *TASPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyStrategies) DeepCopyInto(out *TASPolicyStrategies) {
+	*out = *in
+	out.Deschedule = in.Deschedule.DeepCopy()
+	out.DontSchedule = in.DontSchedule.DeepCopy()
+	out.ScheduleOnMetric = in.ScheduleOnMetric.DeepCopy()
+	out.Labeling = in.Labeling.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyStrategies.
+func (in *TASPolicyStrategies) DeepCopy() *TASPolicyStrategies {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyStrategies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyStrategy) DeepCopyInto(out *TASPolicyStrategy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]TASPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyStrategy.
+func (in *TASPolicyStrategy) DeepCopy() *TASPolicyStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyRule) DeepCopyInto(out *TASPolicyRule) {
+	*out = *in
+	out.Target = in.Target.DeepCopy()
+	if in.Lower != nil {
+		in, out := &in.Lower, &out.Lower
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Upper != nil {
+		in, out := &in.Upper, &out.Upper
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClearTarget != nil {
+		in, out := &in.ClearTarget, &out.ClearTarget
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyRule.
+func (in *TASPolicyRule) DeepCopy() *TASPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyStatus) DeepCopyInto(out *TASPolicyStatus) {
+	*out = *in
+	if in.Strategies != nil {
+		in, out := &in.Strategies, &out.Strategies
+		*out = make(map[string]TASPolicyStrategyStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyStatus.
+func (in *TASPolicyStatus) DeepCopy() *TASPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyStrategyStatus) DeepCopyInto(out *TASPolicyStrategyStatus) {
+	*out = *in
+	if in.LastEnforced != nil {
+		in, out := &in.LastEnforced, &out.LastEnforced
+		*out = (*in).DeepCopy()
+	}
+	if in.ViolatingNodes != nil {
+		in, out := &in.ViolatingNodes, &out.ViolatingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]TASPolicyRuleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyStrategyStatus.
+func (in *TASPolicyStrategyStatus) DeepCopy() *TASPolicyStrategyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyStrategyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TASPolicyRuleStatus) DeepCopyInto(out *TASPolicyRuleStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TASPolicyRuleStatus.
+func (in *TASPolicyRuleStatus) DeepCopy() *TASPolicyRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TASPolicyRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -64,6 +64,13 @@ func (client *Client) Update(obj *telemetrypolicy.TASPolicy) (*telemetrypolicy.T
 	return &result, err
 }
 
+//UpdateStatus changes the status of a given Telemetry Policy through its status subresource
+func (client *Client) UpdateStatus(obj *telemetrypolicy.TASPolicy) (*telemetrypolicy.TASPolicy, error) {
+	var result telemetrypolicy.TASPolicy
+	err := client.rest.Put().Namespace(obj.Namespace).Resource(client.plural).Name(obj.Name).SubResource("status").Body(obj).Do(context.TODO()).Into(&result)
+	return &result, err
+}
+
 //Get returns the full information from the named Telemetry Policy
 func (client *Client) Get(name string, namespace string) (*telemetrypolicy.TASPolicy, error) {
 	var result telemetrypolicy.TASPolicy
@@ -15,9 +15,11 @@ import (
 
 	"github.com/intel/platform-aware-scheduling/extender"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/instrumentation"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
 	telpolv1 "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
 	telpolclient "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/client/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -49,12 +51,12 @@ var testPolicy1 = telpolv1.TASPolicy{
 			"scheduleonmetric": {
 				PolicyName: "test-policy",
 				Rules: []telpolv1.TASPolicyRule{
-					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: 0}},
+					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: resource.MustParse("0")}},
 			},
 			"dontschedule": {
 				PolicyName: "test-policy",
 				Rules: []telpolv1.TASPolicyRule{
-					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: 40},
+					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: resource.MustParse("40")},
 				},
 			},
 		},
@@ -69,18 +71,38 @@ var testPolicy2 = telpolv1.TASPolicy{
 			"scheduleonmetric": {
 				PolicyName: "test-policy",
 				Rules: []telpolv1.TASPolicyRule{
-					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: 0}},
+					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: resource.MustParse("0")}},
 			},
 			"dontschedule": {
 				PolicyName: "test-policy",
 				Rules: []telpolv1.TASPolicyRule{
-					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: 40},
+					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: resource.MustParse("40")},
 				},
 			},
 		},
 	},
 	Status: telpolv1.TASPolicyStatus{},
 }
+var excludingPolicy = telpolv1.TASPolicy{
+	ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Namespace: "default"},
+	Spec: telpolv1.TASPolicySpec{
+		Strategies: map[string]telpolv1.TASPolicyStrategy{
+			"scheduleonmetric": {
+				PolicyName:    "test-policy",
+				MissingMetric: "exclude",
+				Rules: []telpolv1.TASPolicyRule{
+					{Metricname: "dummyMetric1", Operator: "GreaterThan"},
+					{Metricname: "dummyMetric3", Operator: "LessThan", Weight: 2}},
+			},
+			"dontschedule": {
+				PolicyName: "test-policy",
+				Rules: []telpolv1.TASPolicyRule{
+					{Metricname: "dummyMetric1", Operator: "GreaterThan", Target: resource.MustParse("1000")},
+				},
+			},
+		},
+	},
+}
 
 func TestMetricsExtender_prescheduleChecks(t *testing.T) {
 	dummyClient, _, _ := telpolclient.NewRest(*metrics.DummyRestClientConfig())
@@ -292,6 +314,13 @@ func TestMetricsExtender_Filter(t *testing.T) {
 			args:   args{httptest.NewRequest("POST", "http://localhost/scheduler/prioritize", nil), metrics.TestNodeMetricCustomInfo([]string{"node A", "node B"}, []int64{50, 30})},
 			wanted: extender.FilterResult{Nodes: &v1.NodeList{}, NodeNames: &[]string{"node A"}, FailedNodes: map[string]string{"node A": ""}},
 		},
+		{name: "filter out nodes missing a scheduleonmetric metric",
+			fields: fields{*dummyClient, cache.MockSelfUpdatingCache(),
+				metrics.NewDummyMetricsClient(metrics.InstanceOfMockMetricClientMap),
+				excludingPolicy},
+			args:   args{httptest.NewRequest("POST", "http://localhost/scheduler/prioritize", nil), nil},
+			wanted: extender.FilterResult{Nodes: &v1.NodeList{}, NodeNames: &[]string{}, FailedNodes: map[string]string{"node A": "", "node B": ""}},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -341,3 +370,66 @@ func TestMetricsExtender_Filter(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricsExtender_Ready(t *testing.T) {
+	updating := cache.NewAutoUpdatingCache()
+	tests := []struct {
+		name  string
+		cache cache.Reader
+		want  bool
+	}{
+		{"cache which hasn't updated its metrics yet", updating, false},
+		{"cache which does not report updates", struct{ cache.Reader }{cache.MockEmptySelfUpdatingCache()}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMetricsExtender(tt.cache)
+			if got := m.Ready(); got != tt.want {
+				t.Errorf("Ready() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricsExtender_PrioritizeOutcome(t *testing.T) {
+	noSchedulingPolicy := telpolv1.TASPolicy{
+		ObjectMeta: testPolicy1.ObjectMeta,
+		Spec: telpolv1.TASPolicySpec{
+			Strategies: map[string]telpolv1.TASPolicyStrategy{"dontschedule": testPolicy1.Spec.Strategies["dontschedule"]},
+		},
+	}
+	tests := []struct {
+		name        string
+		cache       cache.ReaderWriter
+		policy      telpolv1.TASPolicy
+		args        extender.Args
+		wantOutcome string
+	}{
+		{"scored nodes", cache.MockSelfUpdatingCache(), testPolicy1, twoNodeArgument, "success"},
+		{"pod without a policy", cache.MockSelfUpdatingCache(), testPolicy1, noPolicyPod, "success"},
+		{"policy without a scheduleonmetric strategy", cache.MockSelfUpdatingCache(), noSchedulingPolicy, twoNodeArgument, "success"},
+		{"nodes without the metrics of the strategy", cache.MockEmptySelfUpdatingCache(), testPolicy1, twoNodeArgument, "failure"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMetricsExtender(tt.cache)
+			if err := tt.cache.WritePolicy(tt.policy.Namespace, tt.policy.Name, tt.policy); err != nil {
+				t.Fatal(err)
+			}
+			argsAsJSON, err := json.Marshal(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest("POST", "http://localhost/scheduler/prioritize", bytes.NewReader(argsAsJSON))
+			r.Header.Add("Content-Type", "application/json")
+			requests := instrumentation.ExtenderRequests.WithLabelValues("prioritize", tt.wantOutcome)
+			before := testutil.ToFloat64(requests)
+			m.Prioritize(httptest.NewRecorder(), r)
+			if got := testutil.ToFloat64(requests) - before; got != 1 {
+				t.Errorf("%v prioritize requests counted, want 1", got)
+			}
+		})
+	}
+}
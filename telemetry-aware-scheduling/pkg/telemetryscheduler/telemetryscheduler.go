@@ -6,15 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"github.com/intel/platform-aware-scheduling/extender"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/cache"
-	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/metrics"
-	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/core"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/instrumentation"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/dontschedule"
 	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/strategies/scheduleonmetric"
 	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
@@ -27,6 +28,11 @@ type MetricsExtender struct {
 	cache cache.Reader
 }
 
+//updateReporter is implemented by caches which tell whether they have updated their metrics yet.
+type updateReporter interface {
+	Updated() bool
+}
+
 //NewMetricsExtender returns a new metric Extender with the cache passed to it.
 func NewMetricsExtender(newCache cache.Reader) MetricsExtender {
 	return MetricsExtender{
@@ -34,11 +40,21 @@ func NewMetricsExtender(newCache cache.Reader) MetricsExtender {
 	}
 }
 
+//Ready returns true once the cache has done its first metric update, or right away if the cache isn't updated.
+func (m MetricsExtender) Ready() bool {
+	if reporter, ok := m.cache.(updateReporter); ok {
+		return reporter.Updated()
+	}
+	return true
+}
+
 //Prioritize manages all prioritize requests from the scheduler extender.
 //It decodes the package, checks its policy, and performs error checking.
 //It then calls the prioritize logic and writes a response to the scheduler.
 func (m MetricsExtender) Prioritize(w http.ResponseWriter, r *http.Request) {
 	klog.V(2).InfoS("Received prioritize request", "component", "extender")
+	failed := true
+	defer func(start time.Time) { instrumentation.ObserveExtenderRequest("prioritize", start, failed) }(time.Now())
 	extenderArgs, err := m.DecodeExtenderRequest(r)
 	if err != nil {
 		klog.V(2).InfoS("failed to prioritize: "+err.Error(), "component", "extender")
@@ -52,10 +68,12 @@ func (m MetricsExtender) Prioritize(w http.ResponseWriter, r *http.Request) {
 		klog.V(2).InfoS("no policy associated with pod", "component", "extender")
 		w.WriteHeader(http.StatusBadRequest)
 	}
-	prioritizedNodes := m.prioritizeNodes(extenderArgs)
+	prioritizedNodes, err := m.prioritizeNodes(extenderArgs)
 	if prioritizedNodes == nil {
 		w.WriteHeader(http.StatusNotFound)
 	}
+	//pods without a policy or a scheduleonmetric strategy get no priorities, which isn't a failure
+	failed = err != nil
 	m.WritePrioritizeResponse(w, prioritizedNodes)
 }
 
@@ -79,25 +97,26 @@ func (m MetricsExtender) DecodeExtenderRequest(r *http.Request) (extender.Args,
 }
 
 //prioritizeNodes implements the logic for the prioritize scheduler call.
-func (m MetricsExtender) prioritizeNodes(args extender.Args) *extender.HostPriorityList {
+//It returns an error only if the nodes couldn't be scored, pods without a scheduling strategy get an empty list.
+func (m MetricsExtender) prioritizeNodes(args extender.Args) (*extender.HostPriorityList, error) {
 	policy, err := m.getPolicyFromPod(&args.Pod)
 	if err != nil {
 		klog.V(2).InfoS("get policy from pod failed: "+err.Error(), "component", "extender")
-		return &extender.HostPriorityList{}
+		return &extender.HostPriorityList{}, nil
 	}
-	scheduleRule, err := m.getSchedulingRule(policy)
+	scheduleStrategy, err := m.getSchedulingStrategy(policy)
 	if err != nil {
-		klog.V(2).InfoS("get scheduling rule from policy failed: "+err.Error(), "component", "extender")
-		return &extender.HostPriorityList{}
+		klog.V(2).InfoS("get scheduling strategy from policy failed: "+err.Error(), "component", "extender")
+		return &extender.HostPriorityList{}, nil
 	}
-	chosenNodes, err := m.prioritizeNodesForRule(scheduleRule, args.Nodes)
+	chosenNodes, err := m.prioritizeNodesForStrategy(scheduleStrategy, args.Nodes)
 	if err != nil {
 		klog.V(2).InfoS(err.Error(), "component", "extender")
-		return &extender.HostPriorityList{}
+		return &extender.HostPriorityList{}, err
 	}
 	msg := fmt.Sprintf("node priorities returned: %v", chosenNodes)
 	klog.V(2).InfoS(msg, "component", "extender")
-	return &chosenNodes
+	return &chosenNodes, nil
 }
 
 //getPolicyFromPod returns the policy associated with a pod, if declared, from the api.
@@ -112,43 +131,46 @@ func (m MetricsExtender) getPolicyFromPod(pod *v1.Pod) (telemetrypolicy.TASPolic
 	return telemetrypolicy.TASPolicy{}, fmt.Errorf("no policy found in pod spec for pod %v", pod.Name)
 }
 
-//Does basic validation on the scheduling rule. Returns the rule if it seems useful
-func (m MetricsExtender) getSchedulingRule(policy telemetrypolicy.TASPolicy) (telemetrypolicy.TASPolicyRule, error) {
-	_, ok := policy.Spec.Strategies[scheduleonmetric.StrategyType]
-	if ok && len(policy.Spec.Strategies[scheduleonmetric.StrategyType].Rules) > 0 {
-		out := policy.Spec.Strategies[scheduleonmetric.StrategyType].Rules[0]
-		if len(out.Metricname) > 0 {
-			return out, nil
-		}
+//getSchedulingStrategy pulls the scheduleonmetric strategy from a telemetry policy passed to it, if it has rules.
+func (m MetricsExtender) getSchedulingStrategy(policy telemetrypolicy.TASPolicy) (scheduleonmetric.Strategy, error) {
+	rawStrategy := policy.Spec.Strategies[scheduleonmetric.StrategyType]
+	if len(rawStrategy.Rules) == 0 {
+		return scheduleonmetric.Strategy{}, errors.New("no scheduling rule found")
 	}
-	return telemetrypolicy.TASPolicyRule{}, errors.New("no scheduling rule found")
+	return (scheduleonmetric.Strategy)(rawStrategy), nil
 }
 
-//prioritizeNodesForRule returns the nodes listed in order of priority after applying the appropriate telemetry rule.
-//Priorities are ordinal - there is no relationship between the outputted priorities and the metrics - simply an order of preference.
-func (m MetricsExtender) prioritizeNodesForRule(rule telemetrypolicy.TASPolicyRule, nodes *v1.NodeList) (extender.HostPriorityList, error) {
-	filteredNodeData := metrics.NodeMetricsInfo{}
-	nodeData, err := m.cache.ReadMetric(rule.Metricname)
+//prioritizeNodesForStrategy returns the nodes listed in order of priority after scoring them by the rules of the
+//scheduleonmetric strategy. Priorities are ordinal - there is no relationship between the outputted priorities and
+//the metrics - simply an order of preference, combined over the rules by their weights.
+func (m MetricsExtender) prioritizeNodesForStrategy(strategy scheduleonmetric.Strategy,
+	nodes *v1.NodeList) (extender.HostPriorityList, error) {
+	scores, err := strategy.Scores(m.cache, nodeNames(nodes))
 	if err != nil {
-		return nil, fmt.Errorf("failed to prioritize: %v, %v ", err, rule.Metricname)
-	}
-	// Here we pull out nodes that have metrics but aren't in the filtered list
-	for _, node := range nodes.Items {
-		if v, ok := nodeData[node.Name]; ok {
-			filteredNodeData[node.Name] = v
-		}
+		return nil, fmt.Errorf("failed to prioritize: %w", err)
 	}
 	outputNodes := extender.HostPriorityList{}
-	metricsOutput := fmt.Sprintf("%v for nodes: ", rule.Metricname)
-	orderedNodes := core.OrderedList(filteredNodeData, rule.Operator)
-	for i, node := range orderedNodes {
-		metricsOutput = fmt.Sprint(metricsOutput, " [ ", node.NodeName, " :", node.MetricValue.AsDec(), "]")
-		outputNodes = append(outputNodes, extender.HostPriority{Host: node.NodeName, Score: 10 - i})
+	for nodeName, score := range scores {
+		outputNodes = append(outputNodes, extender.HostPriority{Host: nodeName, Score: score})
 	}
-	klog.V(2).InfoS(metricsOutput, "component", "extender")
+	sort.Slice(outputNodes, func(i, j int) bool {
+		if outputNodes[i].Score != outputNodes[j].Score {
+			return outputNodes[i].Score > outputNodes[j].Score
+		}
+		return outputNodes[i].Host < outputNodes[j].Host
+	})
 	return outputNodes, nil
 }
 
+//nodeNames returns the names of the nodes in the list.
+func nodeNames(nodes *v1.NodeList) []string {
+	names := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
 //WritePrioritizeResponse writes out the results of prioritize in the response to the scheduler.
 func (m MetricsExtender) WritePrioritizeResponse(w http.ResponseWriter, result *extender.HostPriorityList) {
 	encoder := json.NewEncoder(w)
@@ -163,6 +185,8 @@ func (m MetricsExtender) WritePrioritizeResponse(w http.ResponseWriter, result *
 //It then calls the filter logic and writes a response to the scheduler.
 func (m MetricsExtender) Filter(w http.ResponseWriter, r *http.Request) {
 	klog.V(2).InfoS("Filter request received", "component", "extender")
+	failed := true
+	defer func(start time.Time) { instrumentation.ObserveExtenderRequest("filter", start, failed) }(time.Now())
 	extenderArgs, err := m.DecodeExtenderRequest(r)
 	if err != nil {
 		klog.V(2).InfoS("cannot filter "+err.Error(), "component", "extender")
@@ -173,6 +197,7 @@ func (m MetricsExtender) Filter(w http.ResponseWriter, r *http.Request) {
 		klog.V(2).InfoS("No filtered nodes returned", "component", "extender")
 		w.WriteHeader(http.StatusNotFound)
 	}
+	failed = filteredNodes == nil
 	m.WriteFilterResponse(w, filteredNodes)
 }
 
@@ -181,7 +206,15 @@ func (m MetricsExtender) Bind(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotFound)
 }
 
+//Routes returns the metrics endpoint which is served next to the scheduler endpoints.
+func (m MetricsExtender) Routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"/metrics": instrumentation.Handler().ServeHTTP,
+	}
+}
+
 //filterNodes takes in the arguments for the scheduler and filters nodes based on the pod's dontschedule strategy - if it has one in an attached policy.
+//Nodes missing a metric of the scheduleonmetric strategy are filtered too, if the strategy excludes them.
 func (m MetricsExtender) filterNodes(args extender.Args) *extender.FilterResult {
 	availableNodeNames := ""
 	var filteredNodes []v1.Node
@@ -192,21 +225,28 @@ func (m MetricsExtender) filterNodes(args extender.Args) *extender.FilterResult
 		klog.V(2).InfoS("get policy from pod failed "+err.Error(), "component", "extender")
 		return nil
 	}
+	scheduleStrategy := (scheduleonmetric.Strategy)(policy.Spec.Strategies[scheduleonmetric.StrategyType])
 	dontscheduleStrategy, err := m.getDontScheduleStrategy(policy)
-	if err != nil {
+	if err != nil && scheduleStrategy.MissingMetric != scheduleonmetric.ExcludeMissing {
 		klog.V(4).InfoS("Returning all nodes "+err.Error(), "component", "extender")
 		return &extender.FilterResult{
 			Nodes: args.Nodes,
 		}
 	}
-	violatingNodes := dontscheduleStrategy.Violated(m.cache)
+	violatingNodes := map[string]interface{}{}
+	if err == nil {
+		violatingNodes = dontscheduleStrategy.Violated(m.cache)
+	}
 	if len(args.Nodes.Items) == 0 {
 		klog.V(2).InfoS("No nodes to compare", "component", "extender")
 		return nil
 	}
+	excludedNodes := scheduleStrategy.Excluded(m.cache, nodeNames(args.Nodes))
 	for _, node := range args.Nodes.Items {
 		if _, ok := violatingNodes[node.Name]; ok {
 			failedNodes[node.Name] = strings.Join([]string{"Node violates"}, policy.Name)
+		} else if _, ok := excludedNodes[node.Name]; ok {
+			failedNodes[node.Name] = "Node misses a scheduleonmetric metric of " + policy.Name
 		} else {
 			filteredNodes = append(filteredNodes, node)
 			availableNodeNames += node.Name + " "
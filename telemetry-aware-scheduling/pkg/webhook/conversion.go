@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	//ConvertPath is the path of the conversion webhook for Telemetry Policies.
+	ConvertPath = "/convert"
+)
+
+//conversionReview mirrors the apiextensions.k8s.io/v1 ConversionReview the API server sends to the conversion
+//webhook of a CRD, which avoids depending on the apiextensions-apiserver module for a few fields.
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+//convert answers a conversion review of Telemetry Policies between the v1alpha1 and v1beta1 versions.
+func convert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	review := conversionReview{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&review)
+	if err != nil || review.Request == nil {
+		klog.V(2).InfoS("Invalid conversion review received", "component", "webhook")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	review.Response = &conversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+	for _, object := range review.Request.Objects {
+		converted, err := convertPolicy(object.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			klog.V(2).InfoS("Policy not converted: "+err.Error(), "component", "webhook")
+			review.Response.ConvertedObjects = nil
+			review.Response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		review.Response.ConvertedObjects = append(review.Response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		klog.V(2).InfoS("Conversion review not written: "+err.Error(), "component", "webhook")
+	}
+}
+
+//convertPolicy returns the given policy converted to the desired API version.
+func convertPolicy(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("policy cannot be decoded: %w", err)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+	v1alpha1Version := v1alpha1.Group + "/" + v1alpha1.Version
+	v1beta1Version := v1beta1.Group + "/" + v1beta1.Version
+	var converted interface{}
+	switch {
+	case typeMeta.APIVersion == v1alpha1Version && desiredAPIVersion == v1beta1Version:
+		in := v1alpha1.TASPolicy{}
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return nil, fmt.Errorf("policy cannot be decoded: %w", err)
+		}
+		out, dropped := v1beta1.ConvertFromV1alpha1(&in)
+		if len(dropped) > 0 {
+			msg := fmt.Sprintf("Strategies of unknown type %v dropped from policy %v/%v", dropped, in.Namespace, in.Name)
+			klog.V(2).InfoS(msg, "component", "webhook")
+		}
+		out.TypeMeta = metav1.TypeMeta{APIVersion: desiredAPIVersion, Kind: typeMeta.Kind}
+		converted = out
+	case typeMeta.APIVersion == v1beta1Version && desiredAPIVersion == v1alpha1Version:
+		in := v1beta1.TASPolicy{}
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return nil, fmt.Errorf("policy cannot be decoded: %w", err)
+		}
+		out := v1beta1.ConvertToV1alpha1(&in)
+		out.TypeMeta = metav1.TypeMeta{APIVersion: desiredAPIVersion, Kind: typeMeta.Kind}
+		converted = out
+	default:
+		return nil, fmt.Errorf("unsupported conversion from %v to %v", typeMeta.APIVersion, desiredAPIVersion)
+	}
+	output, err := json.Marshal(converted)
+	if err != nil {
+		return nil, fmt.Errorf("policy cannot be encoded: %w", err)
+	}
+	return output, nil
+}
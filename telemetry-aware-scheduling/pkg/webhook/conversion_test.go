@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func conversionReviewBody(desiredAPIVersion string, policy string) []byte {
+	review := conversionReview{Request: &conversionRequest{
+		UID:               "review-uid",
+		DesiredAPIVersion: desiredAPIVersion,
+		Objects:           []runtime.RawExtension{{Raw: []byte(policy)}},
+	}}
+	body, _ := json.Marshal(review)
+	return body
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       []byte
+		wantStatus int
+		wantResult string
+		wantObject string
+	}{
+		{"v1alpha1 to v1beta1",
+			conversionReviewBody("telemetry.intel.com/v1beta1",
+				`{"apiVersion":"telemetry.intel.com/v1alpha1","kind":"TASPolicy","metadata":{"name":"demo"},`+
+					`"spec":{"strategies":{"dontschedule":{"policyName":"demo","rules":[{"metricname":"temperature","operator":"GreaterThan","target":80}]}}}}`),
+			http.StatusOK, "Success",
+			`{"kind":"TASPolicy","apiVersion":"telemetry.intel.com/v1beta1","metadata":{"name":"demo","creationTimestamp":null},` +
+				`"spec":{"strategies":{"dontschedule":{"rules":[{"metricname":"temperature","operator":"GreaterThan","target":"80"}]}}},"status":{}}`},
+		{"v1beta1 to v1alpha1",
+			conversionReviewBody("telemetry.intel.com/v1alpha1",
+				`{"apiVersion":"telemetry.intel.com/v1beta1","kind":"TASPolicy","metadata":{"name":"demo"},`+
+					`"spec":{"strategies":{"scheduleonmetric":{"rules":[{"metricname":"load","operator":"LessThan","target":"1500m"}]}}}}`),
+			http.StatusOK, "Success",
+			`{"kind":"TASPolicy","apiVersion":"telemetry.intel.com/v1alpha1","metadata":{"name":"demo","creationTimestamp":null},` +
+				`"spec":{"strategies":{"scheduleonmetric":{"policyName":"demo","rules":[{"metricname":"load","operator":"LessThan","target":"1500m"}]}}},"status":{}}`},
+		{"unknown strategy type is dropped",
+			conversionReviewBody("telemetry.intel.com/v1beta1",
+				`{"apiVersion":"telemetry.intel.com/v1alpha1","kind":"TASPolicy","metadata":{"name":"demo"},"spec":{"strategies":{"scheduleanywhere":{}}}}`),
+			http.StatusOK, "Success",
+			`{"kind":"TASPolicy","apiVersion":"telemetry.intel.com/v1beta1","metadata":{"name":"demo","creationTimestamp":null},` +
+				`"spec":{"strategies":{}},"status":{}}`},
+		{"unknown version",
+			conversionReviewBody("telemetry.intel.com/v2", `{"apiVersion":"telemetry.intel.com/v1alpha1","kind":"TASPolicy"}`),
+			http.StatusOK, "Failure", ""},
+		{"review without a request", []byte(`{}`), http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, ConvertPath, bytes.NewReader(tt.body))
+			Handler().ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			review := conversionReview{}
+			if err := json.Unmarshal(w.Body.Bytes(), &review); err != nil {
+				t.Fatalf("response is not a conversion review: %v", err)
+			}
+			if review.Response == nil || review.Response.UID != "review-uid" {
+				t.Fatalf("response %+v doesn't answer the request", review.Response)
+			}
+			if review.Response.Result.Status != tt.wantResult {
+				t.Errorf("result = %v, want %v", review.Response.Result.Status, tt.wantResult)
+			}
+			if tt.wantObject == "" {
+				return
+			}
+			if len(review.Response.ConvertedObjects) != 1 {
+				t.Fatalf("converted objects = %v, want 1", len(review.Response.ConvertedObjects))
+			}
+			if got := string(review.Response.ConvertedObjects[0].Raw); got != tt.wantObject {
+				t.Errorf("converted object = %v, want %v", got, tt.wantObject)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+//Package webhook serves the admission and conversion webhooks for Telemetry Policies, which the Kubernetes API
+//server calls before it stores a policy, and to convert policies between the API versions.
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/controller"
+	telemetrypolicy "github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1alpha1"
+	"github.com/intel/platform-aware-scheduling/telemetry-aware-scheduling/pkg/telemetrypolicy/api/v1beta1"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	//ValidatePath is the path of the validating webhook for Telemetry Policies.
+	ValidatePath = "/validate"
+	//maxRequestBytes limits the size of the admission reviews read.
+	maxRequestBytes = 3 * 1024 * 1024
+	//shutdownTimeout is how long in-flight reviews are waited for when the server shuts down.
+	shutdownTimeout = 10 * time.Second
+)
+
+//Handler returns the handler which serves the admission and conversion webhooks.
+func Handler() http.Handler {
+	mx := http.NewServeMux()
+	mx.HandleFunc(ValidatePath, validate)
+	mx.HandleFunc(ConvertPath, convert)
+	return mx
+}
+
+//StartServer serves the admission and conversion webhooks over TLS until the context is done. Unlike the scheduler
+//extender, the webhooks don't require client certificates, as the API server doesn't present one by default.
+func StartServer(ctx context.Context, port, certFile, keyFile string) error {
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		klog.V(2).InfoS("Policy webhooks listening on HTTPS "+port, "component", "webhook")
+		serveErr <- srv.ListenAndServeTLS(certFile, keyFile)
+	}()
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("policy webhook server failed: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("policy webhook server shutdown failed: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("policy webhook server failed: %w", err)
+	}
+	return nil
+}
+
+//validate answers an admission review of a Telemetry Policy, and denies the policy if it doesn't pass
+//controller.ValidatePolicy.
+func validate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	review := admissionv1.AdmissionReview{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&review)
+	if err != nil || review.Request == nil {
+		klog.V(2).InfoS("Invalid admission review received", "component", "webhook")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	review.Response = &admissionv1.AdmissionResponse{UID: review.Request.UID, Allowed: true}
+	if pol, err := decodePolicy(review.Request.Object.Raw); err != nil {
+		review.Response = deny(review.Request.UID, err)
+	} else if err := controller.ValidatePolicy(pol); err != nil {
+		review.Response = deny(review.Request.UID, err)
+	}
+	if !review.Response.Allowed {
+		klog.V(2).InfoS("Policy "+review.Request.Namespace+"/"+review.Request.Name+" denied: "+
+			review.Response.Result.Message, "component", "webhook")
+	}
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		klog.V(2).InfoS("Admission review not written: "+err.Error(), "component", "webhook")
+	}
+}
+
+//decodePolicy returns the policy of an admission request in the v1alpha1 form which the strategies check.
+func decodePolicy(raw []byte) (*telemetrypolicy.TASPolicy, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("policy cannot be decoded: %w", err)
+	}
+	if typeMeta.APIVersion == v1beta1.Group+"/"+v1beta1.Version {
+		pol := v1beta1.TASPolicy{}
+		if err := json.Unmarshal(raw, &pol); err != nil {
+			return nil, fmt.Errorf("policy cannot be decoded: %w", err)
+		}
+		return v1beta1.ConvertToV1alpha1(&pol), nil
+	}
+	pol := telemetrypolicy.TASPolicy{}
+	if err := json.Unmarshal(raw, &pol); err != nil {
+		return nil, fmt.Errorf("policy cannot be decoded: %w", err)
+	}
+	return &pol, nil
+}
+
+//deny returns a response which rejects the object of the admission request with the given error.
+func deny(uid types.UID, err error) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		UID:     uid,
+		Allowed: false,
+		Result:  &metav1.Status{Status: metav1.StatusFailure, Message: err.Error(), Reason: metav1.StatusReasonInvalid},
+	}
+}
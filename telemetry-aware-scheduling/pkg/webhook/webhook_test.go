@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func admissionReview(policy string) []byte {
+	review := admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{UID: "review-uid", Object: runtime.RawExtension{Raw: []byte(policy)}},
+	}
+	review.APIVersion = "admission.k8s.io/v1"
+	review.Kind = "AdmissionReview"
+	body, _ := json.Marshal(review)
+	return body
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		body        []byte
+		wantStatus  int
+		wantAllowed bool
+		wantMessage string
+	}{
+		{"valid policy", http.MethodPost,
+			admissionReview(`{"spec":{"strategies":{"dontschedule":{"rules":[{"metricname":"temperature","operator":"GreaterThan","target":80}]}}}}`),
+			http.StatusOK, true, ""},
+		{"invalid operator", http.MethodPost,
+			admissionReview(`{"spec":{"strategies":{"dontschedule":{"rules":[{"metricname":"temperature","operator":"Above","target":80}]}}}}`),
+			http.StatusOK, false, `dontschedule: rule for temperature has an invalid operator "Above"`},
+		{"invalid v1beta1 policy", http.MethodPost,
+			admissionReview(`{"apiVersion":"telemetry.intel.com/v1beta1","spec":{"strategies":{"scheduleonmetric":{"rules":[]}}}}`),
+			http.StatusOK, false, "scheduleonmetric: strategy has no rules"},
+		{"undecodable policy", http.MethodPost, admissionReview(`{"spec":{"strategies":[]}}`), http.StatusOK, false, ""},
+		{"review without a request", http.MethodPost, []byte(`{}`), http.StatusBadRequest, false, ""},
+		{"wrong method", http.MethodGet, nil, http.StatusMethodNotAllowed, false, ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, ValidatePath, bytes.NewReader(tt.body))
+			Handler().ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			review := admissionv1.AdmissionReview{}
+			if err := json.Unmarshal(w.Body.Bytes(), &review); err != nil {
+				t.Fatalf("response is not an admission review: %v", err)
+			}
+			if review.Response == nil || review.Response.UID != "review-uid" {
+				t.Fatalf("response %+v doesn't answer the request", review.Response)
+			}
+			if review.Response.Allowed != tt.wantAllowed {
+				t.Errorf("allowed = %v, want %v", review.Response.Allowed, tt.wantAllowed)
+			}
+			if tt.wantMessage != "" && review.Response.Result.Message != tt.wantMessage {
+				t.Errorf("message = %v, want %v", review.Response.Result.Message, tt.wantMessage)
+			}
+		})
+	}
+}